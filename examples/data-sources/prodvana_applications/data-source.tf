@@ -0,0 +1,9 @@
+data "prodvana_applications" "payments" {
+  name_regex = "^payments-"
+}
+
+data "prodvana_release_channel" "prod" {
+  for_each    = toset(data.prodvana_applications.payments.names)
+  name        = "prod"
+  application = each.value
+}
@@ -0,0 +1,3 @@
+data "prodvana_ecs_runtime" "example" {
+  name = "my-ecs-runtime"
+}
@@ -0,0 +1,31 @@
+resource "prodvana_k8s_runtime" "example" {
+  name = "my-k8s-runtime"
+}
+
+data "prodvana_k8s_agent_manifest" "example" {
+  runtime = prodvana_k8s_runtime.example.name
+  agent = {
+    namespace = "prodvana"
+    resources = {
+      requests = {
+        cpu    = "100m"
+        memory = "256Mi"
+      }
+    }
+    node_selector = {
+      "kubernetes.io/os" = "linux"
+    }
+  }
+}
+
+# apply the objects in order with the gavinbunney/kubectl provider
+resource "kubectl_manifest" "prodvana_agent" {
+  count     = length(data.prodvana_k8s_agent_manifest.example.objects)
+  yaml_body = data.prodvana_k8s_agent_manifest.example.objects[count.index].manifest
+}
+
+# or commit the whole manifest to a GitOps repository
+resource "local_sensitive_file" "prodvana_agent" {
+  filename = "${path.module}/clusters/my-k8s-runtime/prodvana-agent.yaml"
+  content  = data.prodvana_k8s_agent_manifest.example.manifest
+}
@@ -0,0 +1,8 @@
+data "prodvana_release_channels" "prod" {
+  application = "my-app"
+  name_regex  = "^prod-"
+}
+
+output "prod_release_channels" {
+  value = data.prodvana_release_channels.prod.names
+}
@@ -0,0 +1,38 @@
+data "prodvana_runtimes" "prod" {
+  labels = [
+    {
+      label = "env"
+      value = "prod"
+    },
+  ]
+}
+
+# every runtime with env=prod in us-east-1 or us-west-2, that has a team label
+data "prodvana_runtimes" "prod_us" {
+  label_selector = [
+    {
+      label    = "env"
+      operator = "equals"
+      values   = ["prod"]
+    },
+    {
+      label    = "region"
+      operator = "in"
+      values   = ["us-east-1", "us-west-2"]
+    },
+    {
+      label    = "team"
+      operator = "exists"
+    },
+  ]
+}
+
+resource "prodvana_release_channel" "prod" {
+  name        = "prod"
+  application = "my-app"
+  runtimes = [
+    for runtime in data.prodvana_runtimes.prod_us.names : {
+      runtime = runtime
+    }
+  ]
+}
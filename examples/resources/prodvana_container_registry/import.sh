@@ -0,0 +1 @@
+$ terraform import prodvana_container_registry.example <registry name>
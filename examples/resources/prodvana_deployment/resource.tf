@@ -0,0 +1,18 @@
+# deploy the latest config of a service managed by Terraform
+resource "prodvana_deployment" "staging" {
+  application     = prodvana_service.example.application
+  service         = prodvana_service.example.name
+  release_channel = "staging"
+  version         = prodvana_service.example.version
+}
+
+# or deploy a new image tag on top of the service's current config
+resource "prodvana_deployment" "production" {
+  application     = "my-app"
+  service         = "my-service"
+  release_channel = "production"
+  image_tag       = "v1.2.3"
+  timeout         = "1h"
+
+  depends_on = [prodvana_deployment.staging]
+}
@@ -0,0 +1 @@
+$ terraform import prodvana_ecr_registry.example <registry name>
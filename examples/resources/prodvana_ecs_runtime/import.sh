@@ -0,0 +1 @@
+$ terraform import prodvana_ecs_runtime.example <runtime name>
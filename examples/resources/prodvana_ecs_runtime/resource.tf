@@ -0,0 +1,13 @@
+resource "prodvana_ecs_runtime" "example" {
+  name            = "my-ecs-runtime"
+  region          = "us-west-2"
+  cluster_arn     = "arn:aws:ecs:us-west-2:123456789012:cluster/my-cluster"
+  assume_role_arn = "arn:aws:iam::123456789012:role/prodvana-ecs-access"
+
+  labels = [
+    {
+      label = "env"
+      value = "staging"
+    },
+  ]
+}
@@ -15,6 +15,53 @@ resource "prodvana_managed_k8s_runtime" "example" {
     },
   ]
 
+  agent = {
+    namespace = "prodvana"
+    resources = {
+      requests = {
+        cpu    = "100m"
+        memory = "256Mi"
+      }
+      limits = {
+        memory = "1Gi"
+      }
+    }
+    node_selector = {
+      "kubernetes.io/os" = "linux"
+    }
+    tolerations = [
+      {
+        key      = "dedicated"
+        operator = "Equal"
+        value    = "system"
+        effect   = "NoSchedule"
+      },
+    ]
+    priority_class_name = "system-cluster-critical"
+    security_context = jsonencode({
+      allowPrivilegeEscalation = false
+      capabilities = {
+        drop = ["ALL"]
+      }
+    })
+    rbac = {
+      # only grant access to the namespaces the agent deploys to
+      mode       = "namespaced"
+      namespaces = ["frontend", "backend"]
+    }
+  }
+
   config_path    = "~/.kube/config"
   config_context = "my-k8s-context"
 }
+
+# render the agent objects into a GitOps repository instead of applying them
+resource "prodvana_managed_k8s_runtime" "gitops" {
+  name           = "my-gitops-runtime"
+  install_mode   = "manifests"
+  manifests_path = "${path.module}/clusters/my-gitops-runtime/prodvana-agent.yaml"
+
+  agent = {
+    namespace = "prodvana"
+  }
+}
@@ -0,0 +1 @@
+$ terraform import prodvana_protection.example <protection name>
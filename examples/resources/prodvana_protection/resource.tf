@@ -0,0 +1,42 @@
+resource "prodvana_protection" "example" {
+  name = "http-check"
+  task = {
+    image = "curlimages/curl:8.4.0"
+    cmd   = ["sh", "-c", "curl -sf {{.Params.url}}"]
+  }
+  poll_interval = "30s"
+  timeout       = "5m"
+  parameters = [
+    {
+      name     = "url"
+      type     = "string"
+      required = true
+    },
+  ]
+}
+
+resource "prodvana_release_channel" "example" {
+  name        = "production"
+  application = "my-app"
+  runtimes = [
+    {
+      runtime = "default"
+    }
+  ]
+  protections = [
+    {
+      ref = {
+        name = prodvana_protection.example.name
+        parameters = [
+          {
+            name         = "url"
+            string_value = "https://example.com/healthz"
+          }
+        ]
+      }
+      post_deployment = {
+        enabled = true
+      }
+    }
+  ]
+}
@@ -0,0 +1 @@
+$ terraform import prodvana_release_channel_pipeline.example <application name>/<stage name>,<stage name>,...
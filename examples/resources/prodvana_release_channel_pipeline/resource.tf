@@ -0,0 +1,50 @@
+resource "prodvana_application" "app" {
+  name = "my-app"
+}
+
+# dev -> staging -> prod, each stage waits for the previous one to be stable
+resource "prodvana_release_channel_pipeline" "pipeline" {
+  application = prodvana_application.app.name
+
+  # used by every stage that does not set its own
+  runtimes = [
+    {
+      runtime = "my-runtime"
+    },
+  ]
+  constants = [
+    {
+      name         = "tier"
+      string_value = "pre-prod"
+    },
+  ]
+
+  stages = [
+    {
+      name = "dev"
+    },
+    {
+      name = "staging"
+    },
+    {
+      name = "prod"
+      runtimes = [
+        {
+          runtime = "my-prod-runtime"
+        },
+      ]
+      manual_approval_preconditions = [
+        {
+          name        = "prod-approval"
+          description = "Approve the prod deployment"
+        },
+      ]
+      constants = [
+        {
+          name         = "tier"
+          string_value = "prod"
+        },
+      ]
+    },
+  ]
+}
@@ -0,0 +1 @@
+$ terraform import prodvana_release_channel_protection_attachment.example <application name>/<release channel name>/<attach_to>/<attachment name>
@@ -0,0 +1,25 @@
+# owned by the application team
+resource "prodvana_release_channel" "prod" {
+  name        = "prod"
+  application = "my-app"
+  runtimes = [
+    {
+      runtime = "my-runtime"
+    },
+  ]
+  # leave attachments made by prodvana_release_channel_protection_attachment in place
+  ignore_unmanaged_protections = true
+}
+
+# owned by a central team, e.g. in a separate workspace
+resource "prodvana_release_channel_protection_attachment" "mandatory" {
+  application     = "my-app"
+  release_channel = "prod"
+  name            = "mandatory-check"
+  ref = {
+    name = "my-protection"
+  }
+  deployment = {
+    enabled = true
+  }
+}
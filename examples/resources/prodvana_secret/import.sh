@@ -0,0 +1,2 @@
+# Prodvana never returns secret values, the value is written again on the next apply
+$ terraform import prodvana_secret.example <secret key>
@@ -0,0 +1,31 @@
+# the value is read from the environment when planning and applying,
+# e.g. `export DATABASE_PASSWORD=...`
+resource "prodvana_secret" "db_password" {
+  key       = "database-password"
+  value_env = "DATABASE_PASSWORD"
+}
+
+resource "prodvana_secret" "tls_key" {
+  key        = "tls-key"
+  value_file = "${path.module}/tls.key"
+}
+
+resource "prodvana_release_channel" "staging" {
+  name        = "staging"
+  application = prodvana_application.example.name
+  runtimes = [
+    {
+      runtime = "my-runtime"
+    },
+  ]
+  policy = {
+    default_env = {
+      "DATABASE_PASSWORD" = {
+        secret = {
+          key     = prodvana_secret.db_password.key
+          version = prodvana_secret.db_password.version
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1 @@
+$ terraform import prodvana_service.example <application name>/<service name>
@@ -0,0 +1,33 @@
+resource "prodvana_service" "example" {
+  name        = "my-service"
+  application = "my-app"
+  programs = [
+    {
+      name  = "web"
+      image = "nginx:1.25"
+      ports = [
+        {
+          port        = 80
+          target_port = 8080
+        }
+      ]
+    }
+  ]
+  replicas = 2
+  env = {
+    "LOG_LEVEL" = {
+      value = "info"
+    }
+  }
+  per_release_channel = [
+    {
+      release_channel = "staging"
+      replicas        = 1
+      env = {
+        "LOG_LEVEL" = {
+          value = "debug"
+        }
+      }
+    }
+  ]
+}
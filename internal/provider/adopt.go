@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// adoptExistingSchema is the adopt_existing attribute of resources whose
+// configure call would otherwise silently take over an existing object. It is
+// not computed so that state written before it existed plans no change, null
+// means false.
+func adoptExistingSchema(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Take over an existing %[1]s with the same name when creating this resource. By default creating it fails if the %[1]s already exists, so that two Terraform configurations do not overwrite each other. Only used on create.", kind),
+		Optional:            true,
+	}
+}
+
+// checkNotExists fails unless getErr, the result of reading the object about
+// to be created, shows that it does not exist yet. importId is the id to
+// import the object with, empty if the resource cannot be imported.
+func checkNotExists(kind, name, importId string, getErr error) error {
+	if getErr == nil {
+		if importId == "" {
+			return errors.Errorf("%s %s already exists, set adopt_existing = true to manage it with this resource", kind, name)
+		}
+		return errors.Errorf("%s %s already exists, use terraform import with id %s to manage it, or set adopt_existing = true to take it over on create", kind, name, importId)
+	}
+	if status.Code(errors.Cause(getErr)) == codes.NotFound {
+		return nil
+	}
+	return errors.Wrapf(getErr, "Unable to check if %s %s already exists", kind, name)
+}
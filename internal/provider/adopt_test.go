@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckNotExists(t *testing.T) {
+	testCases := []struct {
+		name     string
+		importId string
+		getErr   error
+		wantErr  string
+	}{
+		{
+			name:   "not found",
+			getErr: status.Error(codes.NotFound, "application foo not found"),
+		},
+		{
+			name:   "wrapped not found",
+			getErr: errors.Wrap(status.Error(codes.NotFound, "application foo not found"), "Unable to read application"),
+		},
+		{
+			name:     "exists",
+			importId: "app/foo",
+			wantErr:  "application foo already exists, use terraform import with id app/foo to manage it, or set adopt_existing = true",
+		},
+		{
+			name:    "exists without import",
+			wantErr: "application foo already exists, set adopt_existing = true",
+		},
+		{
+			name:    "read error",
+			getErr:  status.Error(codes.PermissionDenied, "denied"),
+			wantErr: "Unable to check if application foo already exists",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkNotExists("application", "foo", tc.importId, tc.getErr)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
-	"google.golang.org/grpc"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -49,6 +48,14 @@ func (d *ApplicationDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "Application description",
 				Optional:            true,
 			},
+			"no_cleanup_on_delete": schema.BoolAttribute{
+				MarkdownDescription: "Whether the application is kept when its Terraform resource is destroyed",
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Only used by the `prodvana_application` resource, always null",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -59,18 +66,18 @@ func (d *ApplicationDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = app_pb.NewApplicationManagerClient(conn)
+	d.client = clients.Application
 }
 
 func (d *ApplicationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
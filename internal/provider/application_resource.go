@@ -9,9 +9,9 @@ import (
 	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
 	version_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -35,8 +35,9 @@ func NewApplicationResource() resource.Resource {
 
 // ApplicationResource defines the resource implementation.
 type ApplicationResource struct {
-	client   app_pb.ApplicationManagerClient
-	rcClient rc_pb.ReleaseChannelManagerClient
+	client     app_pb.ApplicationManagerClient
+	rcClient   rc_pb.ReleaseChannelManagerClient
+	onConflict string
 }
 
 // ApplicationResourceModel describes the resource data model.
@@ -46,6 +47,7 @@ type ApplicationResourceModel struct {
 	Id                types.String `tfsdk:"id"`
 	Version           types.String `tfsdk:"version"`
 	NoCleanupOnDelete types.Bool   `tfsdk:"no_cleanup_on_delete"`
+	AdoptExisting     types.Bool   `tfsdk:"adopt_existing"`
 }
 
 func (r *ApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -88,6 +90,7 @@ func (r *ApplicationResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"adopt_existing": adoptExistingSchema("application"),
 		},
 	}
 }
@@ -98,19 +101,20 @@ func (r *ApplicationResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = app_pb.NewApplicationManagerClient(conn)
-	r.rcClient = rc_pb.NewReleaseChannelManagerClient(conn)
+	r.client = clients.Application
+	r.rcClient = clients.ReleaseChannel
+	r.onConflict = clients.OnConflict
 }
 
 func readApplicationData(ctx context.Context, client app_pb.ApplicationManagerClient, data *ApplicationResourceModel) error {
@@ -142,6 +146,62 @@ func (r *ApplicationResource) refresh(ctx context.Context, data *ApplicationReso
 	return readApplicationData(ctx, r.client, data)
 }
 
+// update configures the application from planData. Unless on_conflict is
+// overwrite, the update is conditional on the application not having changed
+// since Terraform read it into stateData.
+func (r *ApplicationResource) update(ctx context.Context, planData, stateData *ApplicationResourceModel) error {
+	for attempt := 0; ; attempt++ {
+		// get current application config so we don't override values that either are not yet supported in TF,
+		// or are updated as separate resources, e.g. Release Channels
+		getAppResp, err := r.client.GetApplication(ctx, &app_pb.GetApplicationReq{
+			Application: planData.Name.ValueString(),
+		})
+		if err != nil {
+			return err
+		}
+
+		appConfig := getAppResp.Application.Config
+		baseVersion := ""
+		if r.onConflict != onConflictOverwrite {
+			baseVersion = getAppResp.Application.Meta.Version
+			if baseVersion != stateData.Version.ValueString() {
+				expected := &app_pb.ApplicationConfig{
+					Name:              stateData.Name.ValueString(),
+					NoCleanupOnDelete: stateData.NoCleanupOnDelete.ValueBool(),
+				}
+				current := &app_pb.ApplicationConfig{
+					Name:              appConfig.Name,
+					NoCleanupOnDelete: appConfig.NoCleanupOnDelete,
+				}
+				// changes to values Terraform does not manage here, e.g. Release Channels, are carried over
+				if !proto.Equal(expected, current) {
+					return conflictError("Application", stateData.Name.ValueString(), stateData.Version.ValueString(), baseVersion, expected, current)
+				}
+			}
+		}
+
+		// this is not really needed since changing the application's name is not supported
+		appConfig.Name = planData.Name.ValueString()
+		appConfig.NoCleanupOnDelete = planData.NoCleanupOnDelete.ValueBool()
+
+		configResp, err := r.client.ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
+			ApplicationConfig: appConfig,
+			Source:            version_pb.Source_IAC,
+			BaseVersion:       baseVersion,
+		})
+		if err != nil {
+			// changed again between reading and configuring it, check it again
+			if isConflict(err) && attempt == 0 {
+				continue
+			}
+			return err
+		}
+		planData.Id = types.StringValue(configResp.Meta.Id)
+		planData.Version = types.StringValue(configResp.Meta.Version)
+		return nil
+	}
+}
+
 func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *ApplicationResourceModel
 
@@ -152,6 +212,16 @@ func (r *ApplicationResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	if !data.AdoptExisting.ValueBool() {
+		_, err := r.client.GetApplication(ctx, &app_pb.GetApplicationReq{
+			Application: data.Name.ValueString(),
+		})
+		if err := checkNotExists("application", data.Name.ValueString(), data.Name.ValueString(), err); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create application, got error: %s", err))
+			return
+		}
+	}
+
 	configResp, err := r.client.ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
 		ApplicationConfig: &app_pb.ApplicationConfig{
 			Name:              data.Name.ValueString(),
@@ -222,32 +292,10 @@ func (r *ApplicationResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// get current application config so we don't override values that either are not yet supported in TF,
-	// or are updated as separate resources, e.g. Release Channels
-	getAppResp, err := r.client.GetApplication(ctx, &app_pb.GetApplicationReq{
-		Application: planData.Name.ValueString(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update application, got error: %s", err))
-		return
-	}
-
-	appConfig := getAppResp.Application.Config
-
-	// this is not really needed since changing the application's name is not supported
-	appConfig.Name = planData.Name.ValueString()
-	appConfig.NoCleanupOnDelete = planData.NoCleanupOnDelete.ValueBool()
-
-	configResp, err := r.client.ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
-		ApplicationConfig: appConfig,
-		Source:            version_pb.Source_IAC,
-	})
-	if err != nil {
+	if err := r.update(ctx, planData, stateData); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update application, got error: %s", err))
 		return
 	}
-	planData.Id = types.StringValue(configResp.Meta.Id)
-	planData.Version = types.StringValue(configResp.Meta.Version)
 
 	// check if description is set
 	if !planData.Description.IsNull() && !planData.Description.IsUnknown() {
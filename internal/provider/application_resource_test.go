@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
 )
 
 func TestAccApplicationResource(t *testing.T) {
@@ -141,6 +144,45 @@ func TestAccApplicationResourceNoCleanupOnDelete(t *testing.T) {
 	})
 }
 
+func TestAccApplicationResourceAdoptExisting(t *testing.T) {
+	if !testAccUseFakeServer {
+		t.Skip("creating the application behind the provider's back requires the fake server")
+	}
+	appName := uniqueTestName("app-tests")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// an existing application is not taken over
+			{
+				PreConfig: func() {
+					_, err := testAccFakeServerClients(t).Application.ConfigureApplication(context.Background(), &app_pb.ConfigureApplicationReq{
+						ApplicationConfig: &app_pb.ApplicationConfig{
+							Name: appName,
+						},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      testAccApplicationResourceConfig(appName),
+				ExpectError: regexp.MustCompile(`already\s+exists,\s+use\s+terraform\s+import`),
+			},
+			// unless asked to
+			{
+				Config: testAccApplicationResourceConfigAdoptExisting(appName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_application.app", "name", appName),
+					resource.TestCheckResourceAttr("prodvana_application.app", "adopt_existing", "true"),
+					resource.TestCheckResourceAttrSet("prodvana_application.app", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 func uniqueTestName(name string) string {
 	prefix := fmt.Sprintf("tf-provider-test-%s-", name)
 	return prefix + acctest.RandStringFromCharSet(40-len(prefix), acctest.CharSetAlphaNum)
@@ -171,3 +213,12 @@ resource "prodvana_application" "app" {
 }
 `, name, noCleanup)
 }
+
+func testAccApplicationResourceConfigAdoptExisting(name string) string {
+	return fmt.Sprintf(`
+resource "prodvana_application" "app" {
+  name = %[1]q
+  adopt_existing = true
+}
+`, name)
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
+	obj_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApplicationsDataSource{}
+
+func NewApplicationsDataSource() datasource.DataSource {
+	return &ApplicationsDataSource{}
+}
+
+// ApplicationsDataSource defines the data source implementation.
+type ApplicationsDataSource struct {
+	client       app_pb.ApplicationManagerClient
+	objectClient obj_pb.ObjectManagerClient
+}
+
+type ApplicationsDataSourceModel struct {
+	Id           types.String                  `tfsdk:"id"`
+	NameRegex    types.String                  `tfsdk:"name_regex"`
+	Labels       []labels.LabelDefinition      `tfsdk:"labels"`
+	Names        []string                      `tfsdk:"names"`
+	Applications []*applicationsDataSourceItem `tfsdk:"applications"`
+}
+
+type applicationsDataSourceItem struct {
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+}
+
+func (d *ApplicationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_applications"
+}
+
+func (d *ApplicationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := listFilterDataSourceAttributes("applications")
+	attributes["applications"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Matching applications, sorted by name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Application identifier",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Application name",
+					Computed:            true,
+				},
+				"version": schema.StringAttribute{
+					MarkdownDescription: "Current application version",
+					Computed:            true,
+				},
+			},
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Prodvana Applications, optionally filtered by name and labels",
+		Attributes:          attributes,
+	}
+}
+
+func (d *ApplicationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.Application
+	d.objectClient = clients.Object
+}
+
+func (d *ApplicationsDataSource) read(ctx context.Context, data *ApplicationsDataSourceModel) error {
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		return err
+	}
+
+	listResp, err := d.client.ListApplications(ctx, &app_pb.ListApplicationsReq{
+		Detailed: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Unable to list applications")
+	}
+
+	apps := listResp.Applications
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Meta.Name < apps[j].Meta.Name
+	})
+
+	data.Id = types.StringValue("applications")
+	data.Names = []string{}
+	data.Applications = []*applicationsDataSourceItem{}
+	for _, app := range apps {
+		if !filter.matchesName(app.Meta.Name) {
+			continue
+		}
+		// application configs don't carry labels, only look them up when filtering on them
+		if filter.hasLabels() {
+			labelsResp, err := d.objectClient.GetLabels(ctx, &obj_pb.GetLabelsReq{
+				Type: obj_pb.ObjectType_APPLICATION,
+				Id:   app.Meta.Id,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "Unable to read labels for application %s", app.Meta.Name)
+			}
+			if !filter.matchesLabels(labelsResp.Labels) {
+				continue
+			}
+		}
+		data.Names = append(data.Names, app.Meta.Name)
+		data.Applications = append(data.Applications, &applicationsDataSourceItem{
+			Id:      types.StringValue(app.Meta.Id),
+			Name:    types.StringValue(app.Meta.Name),
+			Version: types.StringValue(app.Meta.Version),
+		})
+	}
+	return nil
+}
+
+func (d *ApplicationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ApplicationsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := d.read(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list applications, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "read applications data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
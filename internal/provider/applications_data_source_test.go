@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApplicationsDataSource(t *testing.T) {
+	prefix := uniqueTestName("apps-ds")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationsDataSourceConfig(prefix, fmt.Sprintf(`name_regex = "^%s-"`, prefix)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_applications.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.prodvana_applications.test", "names.0", prefix+"-a"),
+					resource.TestCheckResourceAttr("data.prodvana_applications.test", "names.1", prefix+"-b"),
+					resource.TestCheckResourceAttrPair("data.prodvana_applications.test", "applications.1.id", "prodvana_application.b", "id"),
+					resource.TestCheckResourceAttrPair("data.prodvana_applications.test", "applications.1.version", "prodvana_application.b", "version"),
+				),
+			},
+			{
+				Config: testAccApplicationsDataSourceConfig(prefix, fmt.Sprintf(`name_regex = "^%s-a$"`, prefix)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_applications.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.prodvana_applications.test", "applications.0.name", prefix+"-a"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationsDataSourceLabels(t *testing.T) {
+	if !testAccUseFakeServer {
+		t.Skip("application labels can only be set on the fake server")
+	}
+	prefix := uniqueTestName("apps-ds")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			srv, _ := testAccFakeServer()
+			srv.SetApplicationLabels(prefix+"-b", map[string]string{"team": "payments"})
+			t.Cleanup(func() { srv.SetApplicationLabels(prefix+"-b", nil) })
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationsDataSourceConfig(prefix, fmt.Sprintf(`
+  name_regex = "^%s-"
+  labels = [
+    {
+      label = "team"
+      value = "payments"
+    },
+  ]`, prefix)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_applications.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.prodvana_applications.test", "names.0", prefix+"-b"),
+				),
+			},
+		},
+	})
+}
+
+func testAccApplicationsDataSourceConfig(prefix, filter string) string {
+	return fmt.Sprintf(`
+resource "prodvana_application" "a" {
+  name = "%[1]s-a"
+}
+
+resource "prodvana_application" "b" {
+  name = "%[1]s-b"
+}
+
+data "prodvana_applications" "test" {
+  %[2]s
+  depends_on = [prodvana_application.a, prodvana_application.b]
+}
+`, prefix, filter)
+}
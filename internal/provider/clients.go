@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
+	ds_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state"
+	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
+	obj_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	secrets_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/secrets"
+	svc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/service"
+	workflow_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/workflow"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIdMetadataKey = "x-request-id"
+
+// ProdvanaClients holds a client for every Prodvana API service the provider
+// talks to. It is built once in ProdvanaProvider.Configure and handed to
+// resources and data sources as their provider data.
+type ProdvanaClients struct {
+	Environment    env_pb.EnvironmentManagerClient
+	Application    app_pb.ApplicationManagerClient
+	ReleaseChannel rc_pb.ReleaseChannelManagerClient
+	Service        svc_pb.ServiceManagerClient
+	DesiredState   ds_pb.DesiredStateManagerClient
+	Protection     prot_pb.ProtectionManagerClient
+	Workflow       workflow_pb.WorkflowManagerClient
+	Secrets        secrets_pb.SecretsManagerClient
+	Object         obj_pb.ObjectManagerClient
+
+	// OnConflict is the provider on_conflict setting, what resources do
+	// when an object changed outside of Terraform since it was last read.
+	OnConflict string
+
+	// ReleaseChannelLocks serializes resources that read, edit and write
+	// back a release channel they do not own, keyed by
+	// <application>/<release channel>.
+	ReleaseChannelLocks *keyedMutex
+
+	// PlannedProtections holds the parameters of protections changed in the
+	// current plan, see plannedProtections.
+	PlannedProtections *plannedProtections
+}
+
+func NewProdvanaClients(conn *grpc.ClientConn) *ProdvanaClients {
+	return &ProdvanaClients{
+		Environment:    env_pb.NewEnvironmentManagerClient(conn),
+		Application:    app_pb.NewApplicationManagerClient(conn),
+		ReleaseChannel: rc_pb.NewReleaseChannelManagerClient(conn),
+		Service:        svc_pb.NewServiceManagerClient(conn),
+		DesiredState:   ds_pb.NewDesiredStateManagerClient(conn),
+		Protection:     prot_pb.NewProtectionManagerClient(conn),
+		Workflow:       workflow_pb.NewWorkflowManagerClient(conn),
+		Secrets:        secrets_pb.NewSecretsManagerClient(conn),
+		Object:         obj_pb.NewObjectManagerClient(conn),
+
+		ReleaseChannelLocks: newKeyedMutex(),
+		PlannedProtections:  newPlannedProtections(),
+	}
+}
+
+// retryPolicy controls how unaryClientInterceptor retries and bounds calls.
+type retryPolicy struct {
+	// maxAttempts is the total number of attempts, including the first one.
+	maxAttempts int
+	// initialBackoff is the delay before the first retry, it doubles on
+	// every subsequent retry up to maxBackoff.
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	// callTimeout bounds each attempt, unless the caller's context already
+	// has an earlier deadline.
+	callTimeout time.Duration
+}
+
+var defaultRetryPolicy = retryPolicy{
+	maxAttempts:    5,
+	initialBackoff: 500 * time.Millisecond,
+	maxBackoff:     10 * time.Second,
+	callTimeout:    2 * time.Minute,
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+func (p retryPolicy) backoff(retry int) time.Duration {
+	backoff := float64(p.initialBackoff) * math.Pow(2, float64(retry))
+	if backoff > float64(p.maxBackoff) {
+		backoff = float64(p.maxBackoff)
+	}
+	// add up to 20% jitter so parallel resources don't retry in lockstep
+	jitter := backoff * 0.2 * mathrand.Float64()
+	return time.Duration(backoff + jitter)
+}
+
+func newRequestId() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// unaryClientInterceptor tags every call with a request id, bounds each
+// attempt with policy.callTimeout, and retries Unavailable and
+// ResourceExhausted errors with exponential backoff.
+func (p retryPolicy) unaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	requestId := newRequestId()
+	ctx = metadata.AppendToOutgoingContext(ctx, requestIdMetadataKey, requestId)
+	ctx = tflog.SetField(ctx, "prodvana_request_id", requestId)
+	ctx = tflog.SetField(ctx, "prodvana_rpc_method", method)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		callCtx, cancel := context.WithTimeout(ctx, p.callTimeout)
+		err = invoker(callCtx, method, req, reply, cc, opts...)
+		cancel()
+
+		logCtx := tflog.SetField(ctx, "prodvana_rpc_attempt", attempt)
+		logCtx = tflog.SetField(logCtx, "prodvana_rpc_duration", time.Since(start).String())
+		if err == nil {
+			tflog.Debug(logCtx, "Prodvana API call succeeded")
+			return nil
+		}
+		logCtx = tflog.SetField(logCtx, "prodvana_rpc_code", status.Code(err).String())
+
+		if !isRetryable(err) || attempt >= p.maxAttempts || ctx.Err() != nil {
+			tflog.Debug(logCtx, "Prodvana API call failed", map[string]interface{}{"error": err.Error()})
+			return err
+		}
+
+		backoff := p.backoff(attempt - 1)
+		tflog.Warn(logCtx, "Prodvana API call failed, retrying", map[string]interface{}{
+			"error":   err.Error(),
+			"backoff": backoff.String(),
+		})
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
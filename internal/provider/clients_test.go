@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testRetryPolicy = retryPolicy{
+	maxAttempts:    3,
+	initialBackoff: time.Millisecond,
+	maxBackoff:     5 * time.Millisecond,
+	callTimeout:    time.Second,
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	testCases := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantCode     codes.Code
+	}{
+		{
+			name:         "success",
+			errs:         []error{nil},
+			wantAttempts: 1,
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "retries unavailable",
+			errs:         []error{status.Error(codes.Unavailable, ""), status.Error(codes.ResourceExhausted, ""), nil},
+			wantAttempts: 3,
+			wantCode:     codes.OK,
+		},
+		{
+			name:         "does not retry not found",
+			errs:         []error{status.Error(codes.NotFound, ""), nil},
+			wantAttempts: 1,
+			wantCode:     codes.NotFound,
+		},
+		{
+			name:         "gives up after max attempts",
+			errs:         []error{status.Error(codes.Unavailable, ""), status.Error(codes.Unavailable, ""), status.Error(codes.Unavailable, ""), nil},
+			wantAttempts: 3,
+			wantCode:     codes.Unavailable,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			requestIds := map[string]bool{}
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("expected a per-call deadline")
+				}
+				md, _ := metadata.FromOutgoingContext(ctx)
+				for _, id := range md.Get(requestIdMetadataKey) {
+					requestIds[id] = true
+				}
+				err := tc.errs[attempts]
+				attempts++
+				return err
+			}
+			err := testRetryPolicy.unaryClientInterceptor(context.Background(), "/test/Method", nil, nil, nil, invoker)
+			if status.Code(err) != tc.wantCode {
+				t.Errorf("got code %s, want %s", status.Code(err), tc.wantCode)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, tc.wantAttempts)
+			}
+			if len(requestIds) != 1 {
+				t.Errorf("expected one request id across all attempts, got %v", requestIds)
+			}
+		})
+	}
+}
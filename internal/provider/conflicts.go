@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// on_conflict settings, what to do when an object changed outside of
+// Terraform since it was last read
+const (
+	onConflictFail      = "fail"
+	onConflictOverwrite = "overwrite"
+)
+
+var onConflictSettings = []string{onConflictFail, onConflictOverwrite}
+
+// isConflict reports whether err is the API rejecting a configure call made
+// against an outdated base version.
+func isConflict(err error) bool {
+	return status.Code(errors.Cause(err)) == codes.FailedPrecondition
+}
+
+// conflictError describes an object that changed outside of Terraform, with
+// the difference between what Terraform last read and what it is now.
+func conflictError(kind, name, stateVersion, currentVersion string, expected, current proto.Message) error {
+	return errors.Errorf("%s %s was changed outside of Terraform, it is at version %s instead of version %s in the Terraform state. Changes since Terraform last read it:\n%s\nRun terraform plan to review the change, or set on_conflict = %q on the provider to overwrite it.",
+		kind, name, currentVersion, stateVersion, configDiff(expected, current), onConflictOverwrite)
+}
+
+// configDiff renders a line diff of two configs, - for lines only in before
+// and + for lines only in after.
+func configDiff(before, after proto.Message) string {
+	opts := prototext.MarshalOptions{Multiline: true}
+	beforeLines := strings.Split(strings.TrimSpace(opts.Format(before)), "\n")
+	afterLines := strings.Split(strings.TrimSpace(opts.Format(after)), "\n")
+
+	// longest common subsequence of the lines, lcs[i][j] is the length of
+	// the one of beforeLines[i:] and afterLines[j:]
+	lcs := make([][]int, len(beforeLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(afterLines)+1)
+	}
+	for i := len(beforeLines) - 1; i >= 0; i-- {
+		for j := len(afterLines) - 1; j >= 0; j-- {
+			if strings.TrimSpace(beforeLines[i]) == strings.TrimSpace(afterLines[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(beforeLines) || j < len(afterLines) {
+		switch {
+		case i < len(beforeLines) && j < len(afterLines) && strings.TrimSpace(beforeLines[i]) == strings.TrimSpace(afterLines[j]):
+			diff = append(diff, fmt.Sprintf("  %s", afterLines[j]))
+			i++
+			j++
+		case i < len(beforeLines) && (j == len(afterLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, fmt.Sprintf("- %s", beforeLines[i]))
+			i++
+		default:
+			diff = append(diff, fmt.Sprintf("+ %s", afterLines[j]))
+			j++
+		}
+	}
+	return strings.Join(diff, "\n")
+}
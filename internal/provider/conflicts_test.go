@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/fakeserver"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newConflictTestClients(t *testing.T) *ProdvanaClients {
+	srv, err := fakeserver.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	conn, err := srv.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewProdvanaClients(conn)
+}
+
+func configureTestReleaseChannel(t *testing.T, clients *ProdvanaClients, tier string) {
+	_, err := clients.ReleaseChannel.ConfigureReleaseChannel(context.Background(), &rc_pb.ConfigureReleaseChannelReq{
+		Application: "conflicts",
+		ReleaseChannel: &rc_pb.ReleaseChannelConfig{
+			Name: "test",
+			Runtimes: []*rc_pb.ReleaseChannelRuntimeConfig{
+				{
+					Runtime: fakeserver.DefaultRuntime,
+				},
+			},
+			Constants: []*common_config_pb.Constant{
+				{
+					Name: "tier",
+					ConfigOneof: &common_config_pb.Constant_String_{
+						String_: &common_config_pb.StringConstant{Value: tier},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReleaseChannelUpdateConflict(t *testing.T) {
+	ctx := context.Background()
+	clients := newConflictTestClients(t)
+	_, err := clients.Application.ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
+		ApplicationConfig: &app_pb.ApplicationConfig{Name: "conflicts"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureTestReleaseChannel(t, clients, "staging")
+	r := &ReleaseChannelResource{
+		client:           clients.ReleaseChannel,
+		protectionClient: clients.Protection,
+		onConflict:       onConflictFail,
+	}
+	readState := func() *ReleaseChannelResourceModel {
+		data := &ReleaseChannelResourceModel{
+			Application:                types.StringValue("conflicts"),
+			Name:                       types.StringValue("test"),
+			IgnoreUnmanagedProtections: types.BoolValue(false),
+		}
+		if err := r.refresh(ctx, data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	// a new version with the same config is not a conflict
+	state := readState()
+	configureTestReleaseChannel(t, clients, "staging")
+	plan := *state
+	if err := r.createOrUpdate(ctx, &plan, state); err != nil {
+		t.Fatalf("expected no conflict, got: %s", err)
+	}
+
+	state = readState()
+	configureTestReleaseChannel(t, clients, "prod")
+	plan = *state
+	err = r.createOrUpdate(ctx, &plan, state)
+	if err == nil {
+		t.Fatal("expected a conflict")
+	}
+	for _, want := range []string{"changed outside of Terraform", `- value: "staging"`, `+ value: "prod"`} {
+		// prototext output randomly varies its spacing
+		if !strings.Contains(strings.Join(strings.Fields(err.Error()), " "), want) {
+			t.Errorf("expected %q in error:\n%s", want, err)
+		}
+	}
+
+	r.onConflict = onConflictOverwrite
+	plan = *state
+	if err := r.createOrUpdate(ctx, &plan, state); err != nil {
+		t.Fatal(err)
+	}
+	if tier := readState().Constants[0].StringValue.ValueString(); tier != "staging" {
+		t.Errorf("expected the release channel to be overwritten, got tier %s", tier)
+	}
+}
+
+func TestApplicationUpdateConflict(t *testing.T) {
+	ctx := context.Background()
+	clients := newConflictTestClients(t)
+	_, err := clients.Application.ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
+		ApplicationConfig: &app_pb.ApplicationConfig{Name: "conflicts"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &ApplicationResource{
+		client:     clients.Application,
+		rcClient:   clients.ReleaseChannel,
+		onConflict: onConflictFail,
+	}
+	readState := func() *ApplicationResourceModel {
+		data := &ApplicationResourceModel{
+			Name: types.StringValue("conflicts"),
+		}
+		if err := r.refresh(ctx, data); err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	// release channels are managed separately and carried over
+	state := readState()
+	configureTestReleaseChannel(t, clients, "staging")
+	plan := *state
+	if err := r.update(ctx, &plan, state); err != nil {
+		t.Fatalf("expected no conflict, got: %s", err)
+	}
+	getAppResp, err := clients.Application.GetApplication(ctx, &app_pb.GetApplicationReq{Application: "conflicts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(getAppResp.Application.Config.ReleaseChannels) != 1 {
+		t.Errorf("expected the release channel to be kept, got %d", len(getAppResp.Application.Config.ReleaseChannels))
+	}
+
+	state = readState()
+	_, err = clients.Application.ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
+		ApplicationConfig: &app_pb.ApplicationConfig{
+			Name:              "conflicts",
+			NoCleanupOnDelete: true,
+			ReleaseChannels:   getAppResp.Application.Config.ReleaseChannels,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan = *state
+	err = r.update(ctx, &plan, state)
+	if err == nil {
+		t.Fatal("expected a conflict")
+	}
+	for _, want := range []string{"changed outside of Terraform", "+ no_cleanup_on_delete: true"} {
+		// prototext output randomly varies its spacing
+		if !strings.Contains(strings.Join(strings.Fields(err.Error()), " "), want) {
+			t.Errorf("expected %q in error:\n%s", want, err)
+		}
+	}
+
+	r.onConflict = onConflictOverwrite
+	plan = *state
+	if err := r.update(ctx, &plan, state); err != nil {
+		t.Fatal(err)
+	}
+	if readState().NoCleanupOnDelete.ValueBool() {
+		t.Error("expected the application to be overwritten")
+	}
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+)
+
+type constant struct {
+	Name        types.String `tfsdk:"name"`
+	StringValue types.String `tfsdk:"string_value"`
+}
+
+// constantNestedObjectSchema is the schema for a single constant, shared by
+// release channels and service configs.
+func constantNestedObjectSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "name of the constant",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"string_value": schema.StringAttribute{
+				MarkdownDescription: "string value of the constant, null when read back for a constant of a type the provider does not support",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func constantsToProtos(constants []*constant) []*common_config_pb.Constant {
+	protos := []*common_config_pb.Constant{}
+	for _, c := range constants {
+		protos = append(protos, &common_config_pb.Constant{
+			Name: c.Name.ValueString(),
+			ConfigOneof: &common_config_pb.Constant_String_{
+				String_: &common_config_pb.StringConstant{
+					Value: c.StringValue.ValueString(),
+				},
+			},
+		})
+	}
+	return protos
+}
+
+// constantsFromProtos converts constants read from the API. Constants of a
+// type the provider does not support, e.g. set in the Prodvana UI, are kept
+// without a value so that they show up as a planned change.
+func constantsFromProtos(protos []*common_config_pb.Constant) []*constant {
+	constants := []*constant{}
+	for _, c := range protos {
+		tfConstant := &constant{
+			Name:        types.StringValue(c.Name),
+			StringValue: types.StringNull(),
+		}
+		switch c.ConfigOneof.(type) {
+		case *common_config_pb.Constant_String_:
+			tfConstant.StringValue = types.StringValue(c.GetString_().GetValue())
+		}
+		constants = append(constants, tfConstant)
+	}
+	return constants
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+)
+
+func TestConstantsFromProtos(t *testing.T) {
+	constants := constantsFromProtos([]*common_config_pb.Constant{
+		{
+			Name: "tier",
+			ConfigOneof: &common_config_pb.Constant_String_{
+				String_: &common_config_pb.StringConstant{Value: "prod"},
+			},
+		},
+		// e.g. a constant type newer than this provider, set in the UI
+		{
+			Name: "unsupported",
+		},
+	})
+	if len(constants) != 2 {
+		t.Fatalf("expected 2 constants, got %d", len(constants))
+	}
+	if constants[0].Name.ValueString() != "tier" || constants[0].StringValue.ValueString() != "prod" {
+		t.Errorf("expected tier = prod, got %s = %s", constants[0].Name, constants[0].StringValue)
+	}
+	if constants[1].Name.ValueString() != "unsupported" || !constants[1].StringValue.IsNull() {
+		t.Errorf("expected unsupported without a value, got %s = %s", constants[1].Name, constants[1].StringValue)
+	}
+}
@@ -19,13 +19,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ContainerRegistryResource{}
+var _ resource.ResourceWithImportState = &ContainerRegistryResource{}
 
 func NewContainerRegistryResource() resource.Resource {
 	return &ContainerRegistryResource{}
@@ -44,6 +44,8 @@ type ContainerRegistryResourceModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	Public   types.Bool   `tfsdk:"public"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
 }
 
 func (r *ContainerRegistryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -103,6 +105,7 @@ func (r *ContainerRegistryResource) Schema(ctx context.Context, req resource.Sch
 					),
 				},
 			},
+			"adopt_existing": adoptExistingSchema("container registry"),
 		},
 	}
 }
@@ -113,18 +116,18 @@ func (r *ContainerRegistryResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = workflow_pb.NewWorkflowManagerClient(conn)
+	r.client = clients.Workflow
 }
 
 func (r *ContainerRegistryResource) refresh(ctx context.Context, diags diag.Diagnostics, data *ContainerRegistryResourceModel) error {
@@ -175,6 +178,16 @@ func (r *ContainerRegistryResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	if !data.AdoptExisting.ValueBool() {
+		_, err := r.client.GetContainerRegistryIntegration(ctx, &workflow_pb.GetContainerRegistryIntegrationReq{
+			RegistryName: data.Name.ValueString(),
+		})
+		if err := checkNotExists("container registry", data.Name.ValueString(), data.Name.ValueString(), err); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create container registry, got error: %s", err))
+			return
+		}
+	}
+
 	err := r.createOrUpdate(ctx, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create container registry, got error: %s", err))
@@ -255,3 +268,24 @@ func (r *ContainerRegistryResource) Delete(ctx context.Context, req resource.Del
 
 	tflog.Trace(ctx, "deleted container registry resource")
 }
+
+func (r *ContainerRegistryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var data ContainerRegistryResourceModel
+
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the credentials cannot be read back, they are set on the next apply
+	data.Name = types.StringValue(req.ID)
+	data.Public = types.BoolValue(false)
+	err := r.refresh(ctx, resp.Diagnostics, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import container registry state for %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	// Save imported data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
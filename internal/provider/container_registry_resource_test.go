@@ -62,6 +62,14 @@ func TestAccContainerRegistryResourcePublic(t *testing.T) {
 					resource.TestCheckNoResourceAttr("prodvana_container_registry.test", "password"),
 				),
 			},
+			// ImportState testing, whether the registry is public cannot be read back
+			{
+				ResourceName:            "prodvana_container_registry.test",
+				ImportStateId:           name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"public"},
+			},
 			// Update and Read test
 			{
 				Config: testAccK8sContainerRegistryResourcePublic(name, "https://index.docker.io"),
@@ -71,17 +71,17 @@ type envDefaultPathListValue struct {
 
 // Description returns a plain text description of the default's behavior, suitable for a practitioner to understand its impact.
 func (d envDefaultPathListValue) Description(ctx context.Context) string {
-	return fmt.Sprintf("If this value is not passed, it defaults to the value of %s (comma separated)", d.envName)
+	return fmt.Sprintf("If this value is not passed, it defaults to the value of %s (separated like PATH, by ':' or ';' on Windows)", d.envName)
 }
 
 // MarkdownDescription returns a markdown formatted description of the default's behavior, suitable for a practitioner to understand its impact.
 func (d envDefaultPathListValue) MarkdownDescription(ctx context.Context) string {
-	return fmt.Sprintf("If this value is not passed, it defaults to the value of %s (comma separated)", d.envName)
+	return fmt.Sprintf("If this value is not passed, it defaults to the value of %s (separated like PATH, by ':' or ';' on Windows)", d.envName)
 }
 
 func (d envDefaultPathListValue) DefaultList(ctx context.Context, req defaults.ListRequest, resp *defaults.ListResponse) {
 	value := os.Getenv(d.envName)
-	if value != "" {
+	if value == "" {
 		resp.PlanValue = types.ListNull(types.StringType)
 	} else {
 		paths := filepath.SplitList(value)
@@ -0,0 +1,52 @@
+package defaults
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnvPathListValue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("TEST_KUBE_CONFIG_PATHS", "")
+	resp := &defaults.ListResponse{}
+	EnvPathListValue("TEST_KUBE_CONFIG_PATHS").DefaultList(ctx, defaults.ListRequest{}, resp)
+	if !resp.PlanValue.IsNull() {
+		t.Errorf("expected null without the env var, got %s", resp.PlanValue)
+	}
+
+	t.Setenv("TEST_KUBE_CONFIG_PATHS", strings.Join([]string{"/a/config", "/b/config"}, string(filepath.ListSeparator)))
+	resp = &defaults.ListResponse{}
+	EnvPathListValue("TEST_KUBE_CONFIG_PATHS").DefaultList(ctx, defaults.ListRequest{}, resp)
+	var paths []string
+	resp.Diagnostics.Append(resp.PlanValue.ElementsAs(ctx, &paths, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	if strings.Join(paths, ",") != "/a/config,/b/config" {
+		t.Errorf("expected both paths, got %v", paths)
+	}
+}
+
+func TestEnvStringValue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("TEST_KUBE_HOST", "")
+	resp := &defaults.StringResponse{}
+	EnvStringValue("TEST_KUBE_HOST").DefaultString(ctx, defaults.StringRequest{}, resp)
+	if !resp.PlanValue.IsNull() {
+		t.Errorf("expected null without the env var, got %s", resp.PlanValue)
+	}
+
+	t.Setenv("TEST_KUBE_HOST", "https://example.com")
+	resp = &defaults.StringResponse{}
+	EnvStringValue("TEST_KUBE_HOST").DefaultString(ctx, defaults.StringRequest{}, resp)
+	if !resp.PlanValue.Equal(types.StringValue("https://example.com")) {
+		t.Errorf("expected the env var value, got %s", resp.PlanValue)
+	}
+}
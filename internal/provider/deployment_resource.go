@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	ds_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state"
+	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state/model"
+	svc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/service"
+	version_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DeploymentResource{}
+var _ resource.ResourceWithValidateConfig = &DeploymentResource{}
+
+func NewDeploymentResource() resource.Resource {
+	return &DeploymentResource{}
+}
+
+// DeploymentResource defines the resource implementation.
+type DeploymentResource struct {
+	client        ds_pb.DesiredStateManagerClient
+	serviceClient svc_pb.ServiceManagerClient
+}
+
+// DeploymentResourceModel describes the resource data model.
+type DeploymentResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Application        types.String `tfsdk:"application"`
+	Service            types.String `tfsdk:"service"`
+	ReleaseChannel     types.String `tfsdk:"release_channel"`
+	Version            types.String `tfsdk:"version"`
+	ImageTag           types.String `tfsdk:"image_tag"`
+	Program            types.String `tfsdk:"program"`
+	WaitForConvergence types.Bool   `tfsdk:"wait_for_convergence"`
+	Timeout            types.String `tfsdk:"timeout"`
+	DeployedVersion    types.String `tfsdk:"deployed_version"`
+	Status             types.String `tfsdk:"status"`
+}
+
+func (r *DeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+func (r *DeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `(Alpha! This feature is still in progress.)
+A ` + "`deployment`" + ` resource pushes a service version, or an image tag built on top of the service's current config, to a release channel.
+By default the resource waits for the deployment to converge and fails the apply if it does not.
+Changing ` + "`version`" + `, ` + "`image_tag`" + `, or ` + "`program`" + ` triggers a new deployment. Destroying the resource does not roll anything back, it only removes it from Terraform state.
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Desired state identifier of the most recent deployment",
+			},
+			"application": schema.StringAttribute{
+				MarkdownDescription: "Name of the Application the Service belongs to",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "Name of the Service to deploy",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"release_channel": schema.StringAttribute{
+				MarkdownDescription: "Name of the Release Channel to deploy to",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Service version to deploy, e.g. `prodvana_service.example.version`. Exactly one of `version` or `image_tag` must be set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("image_tag")),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"image_tag": schema.StringAttribute{
+				MarkdownDescription: "Image tag to deploy. A new service version is created from the service's current config with this tag set on its programs that use `image_registry`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"program": schema.StringAttribute{
+				MarkdownDescription: "Only set `image_tag` on this program. Defaults to every program that uses `image_registry`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("image_tag")),
+				},
+			},
+			"wait_for_convergence": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the deployment to converge. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the deployment to converge. A valid Go duration string, e.g. `10m` or `1h`. Defaults to `30m`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30m"),
+			},
+			"deployed_version": schema.StringAttribute{
+				MarkdownDescription: "Service version that was deployed",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Convergence status of the deployment, e.g. `CONVERGED`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.DesiredState
+	r.serviceClient = clients.Service
+}
+
+// ValidateConfig checks timeout at plan time, an invalid one would otherwise
+// only fail the apply after the deployment went out.
+func (r *DeploymentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var timeout types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("timeout"), &timeout)...)
+	if resp.Diagnostics.HasError() || timeout.IsNull() || timeout.IsUnknown() {
+		return
+	}
+	if _, err := time.ParseDuration(timeout.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid Duration", fmt.Sprintf("timeout must be a valid Go duration string, got error: %s", err))
+	}
+}
+
+// versionForImageTag creates a new service version from the service's
+// current config with image_tag set on the selected programs.
+func (r *DeploymentResource) versionForImageTag(ctx context.Context, data *DeploymentResourceModel) (string, error) {
+	configResp, err := r.serviceClient.GetServiceConfig(ctx, &svc_pb.GetServiceConfigReq{
+		Application: data.Application.ValueString(),
+		Service:     data.Service.ValueString(),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "Unable to read service config for %s", data.Service.ValueString())
+	}
+	config := configResp.InputConfig
+	if config == nil {
+		config = configResp.Config
+	}
+	if config == nil {
+		return "", errors.Errorf("Service %s has no config", data.Service.ValueString())
+	}
+	config = proto.Clone(config).(*svc_pb.ServiceConfig)
+
+	program := data.Program.ValueString()
+	updated := 0
+	for _, p := range config.Programs {
+		if program != "" && p.Name != program {
+			continue
+		}
+		if p.ImageRegistryInfo == nil {
+			if program != "" {
+				return "", errors.Errorf("program %s does not use image_registry, cannot set image_tag", program)
+			}
+			continue
+		}
+		p.ImageTag = data.ImageTag.ValueString()
+		updated++
+	}
+	if updated == 0 {
+		if program != "" {
+			return "", errors.Errorf("program %s not found in service %s", program, data.Service.ValueString())
+		}
+		return "", errors.Errorf("service %s has no programs that use image_registry, cannot set image_tag", data.Service.ValueString())
+	}
+
+	applyResp, err := r.serviceClient.ApplyParameters(ctx, &svc_pb.ApplyParametersReq{
+		Oneof: &svc_pb.ApplyParametersReq_ServiceConfig{
+			ServiceConfig: config,
+		},
+		Application: data.Application.ValueString(),
+		Source:      version_pb.Source_IAC,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "Unable to create service version with image tag %s", data.ImageTag.ValueString())
+	}
+	return applyResp.Version, nil
+}
+
+// deploy pushes the planned version to the release channel and, if asked
+// to, waits for it to converge. data is updated even if waiting fails so the
+// failed deployment can be recorded.
+func (r *DeploymentResource) deploy(ctx context.Context, data *DeploymentResourceModel) error {
+	version := data.Version.ValueString()
+	if !data.ImageTag.IsNull() {
+		var err error
+		version, err = r.versionForImageTag(ctx, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	setResp, err := r.client.SetDesiredState(ctx, &ds_pb.SetDesiredStateReq{
+		DesiredState: &model.State{
+			StateOneof: &model.State_Service{
+				Service: &model.ServiceState{
+					Application: data.Application.ValueString(),
+					Service:     data.Service.ValueString(),
+					ReleaseChannels: []*model.ServiceInstanceState{
+						{
+							ReleaseChannel: data.ReleaseChannel.ValueString(),
+							Versions: []*model.Version{
+								{
+									Version: version,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Source: version_pb.Source_IAC,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to deploy version %s", version)
+	}
+
+	desiredStateId := setResp.GetDesiredStateId()
+	if desiredStateId == "" {
+		return errors.Errorf("Unable to deploy version %s, no desired state was created", version)
+	}
+	data.Id = types.StringValue(desiredStateId)
+	data.DeployedVersion = types.StringValue(version)
+	data.Status = types.StringValue(model.Status_CONVERGING.String())
+
+	if !data.WaitForConvergence.ValueBool() {
+		return nil
+	}
+	convergenceStatus, err := WaitForDesiredStateWithTimeout(ctx, r.client, desiredStateId, data.Timeout.ValueString())
+	data.Status = types.StringValue(convergenceStatus.String())
+	return err
+}
+
+func (r *DeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *DeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.deploy(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed deploying %s to %s: %s", data.Service.ValueString(), data.ReleaseChannel.ValueString(), err))
+		if !data.Id.IsUnknown() {
+			// record the failed deployment so it is tainted and retried on the next apply
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
+		return
+	}
+
+	tflog.Trace(ctx, "created deployment resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *DeploymentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	summaryResp, err := r.client.GetDesiredStateConvergenceSummary(ctx, &ds_pb.GetDesiredStateConvergenceReq{
+		DesiredStateId: data.Id.ValueString(),
+	})
+	if err != nil {
+		// if the desired state does not exist, remove the resource
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read deployment state for %s, got error: %s", data.Id.ValueString(), err))
+		return
+	}
+	data.Status = types.StringValue(summaryResp.GetSummary().GetStatus().String())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData *DeploymentResourceModel
+	var stateData *DeploymentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planData.Version.Equal(stateData.Version) && planData.ImageTag.Equal(stateData.ImageTag) && planData.Program.Equal(stateData.Program) {
+		// only wait_for_convergence or timeout changed, nothing to deploy
+		planData.Id = stateData.Id
+		planData.DeployedVersion = stateData.DeployedVersion
+		planData.Status = stateData.Status
+	} else {
+		err := r.deploy(ctx, planData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed deploying %s to %s: %s", planData.Service.ValueString(), planData.ReleaseChannel.ValueString(), err))
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated deployment resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *DeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// deployments cannot be undone, destroying the resource only removes it from state
+	tflog.Trace(ctx, "deleted deployment resource")
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	ds_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state"
+	"google.golang.org/grpc"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeploymentResource(t *testing.T) {
+	appName := uniqueTestName("deploy-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Deploy the service's current version
+			{
+				Config: testAccDeploymentResourceConfig(appName, `version = prodvana_service.test.version`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("prodvana_deployment.test", "id"),
+					resource.TestCheckResourceAttr("prodvana_deployment.test", "status", "CONVERGED"),
+					resource.TestCheckResourceAttrPair("prodvana_deployment.test", "deployed_version", "prodvana_service.test", "version"),
+				),
+			},
+			// Deploy an image tag on top of the service's current config
+			{
+				Config: testAccDeploymentResourceConfig(appName, `image_tag = "v2"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("prodvana_deployment.test", "id"),
+					resource.TestCheckResourceAttr("prodvana_deployment.test", "status", "CONVERGED"),
+					resource.TestCheckResourceAttr("prodvana_deployment.test", "image_tag", "v2"),
+					resource.TestCheckResourceAttrSet("prodvana_deployment.test", "deployed_version"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDeploymentResourceFailedConvergence(t *testing.T) {
+	if !testAccUseFakeServer {
+		t.Skip("forcing a deployment to fail requires the fake server")
+	}
+	appName := uniqueTestName("deploy-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			srv, _ := testAccFakeServer()
+			srv.SetConvergenceFailure(appName, "my-service", "container crashed")
+			t.Cleanup(func() { srv.SetConvergenceFailure(appName, "my-service", "") })
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDeploymentResourceConfig(appName, `version = prodvana_service.test.version`),
+				ExpectError: regexp.MustCompile(`status: FAILED: container\s+crashed`),
+			},
+		},
+	})
+}
+
+func TestAccDeploymentResourceInvalidTimeout(t *testing.T) {
+	appName := uniqueTestName("deploy-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeploymentResourceConfig(appName, `version = prodvana_service.test.version
+  timeout = "30"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`timeout\s+must\s+be\s+a\s+valid\s+Go\s+duration\s+string`),
+			},
+		},
+	})
+}
+
+// emptySummaryClient returns a convergence summary response without a summary.
+type emptySummaryClient struct {
+	ds_pb.DesiredStateManagerClient
+}
+
+func (c *emptySummaryClient) GetDesiredStateConvergenceSummary(ctx context.Context, in *ds_pb.GetDesiredStateConvergenceReq, opts ...grpc.CallOption) (*ds_pb.GetDesiredStateConvergenceSummaryResp, error) {
+	return &ds_pb.GetDesiredStateConvergenceSummaryResp{}, nil
+}
+
+func TestDeploymentResourceReadEmptySummary(t *testing.T) {
+	ctx := context.Background()
+	r := &DeploymentResource{client: &emptySummaryClient{}}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &DeploymentResourceModel{
+		Id:     types.StringValue("ds-1"),
+		Status: types.StringValue("CONVERGED"),
+	}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var data DeploymentResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.Status.ValueString() != "UNKNOWN_STATUS" {
+		t.Errorf("expected status UNKNOWN_STATUS, got %s", data.Status)
+	}
+}
+
+func testAccDeploymentResourceConfig(app, target string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "prodvana_release_channel" "staging" {
+  name        = "staging"
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+}
+
+resource "prodvana_service" "test" {
+  name        = "my-service"
+  application = prodvana_application.app.name
+  programs = [
+    {
+      name = "web"
+      image_registry = {
+        container_registry = "dockerhub"
+        repository         = "library/nginx"
+      }
+      image_tag = "v1"
+    },
+  ]
+}
+
+resource "prodvana_deployment" "test" {
+  application     = prodvana_application.app.name
+  service         = prodvana_service.test.name
+  release_channel = prodvana_release_channel.staging.name
+  %[2]s
+}
+`, testAccApplicationResourceConfig(app), target)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -15,13 +16,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ECRRegistryResource{}
+var _ resource.ResourceWithImportState = &ECRRegistryResource{}
 
 func NewECRRegistryResource() resource.Resource {
 	return &ECRRegistryResource{}
@@ -41,6 +42,8 @@ type ECRRegistryResourceModel struct {
 	// this is encapsulated in its own nested object because we will
 	// support other authentication methods in the future
 	CredentialsAuth *CredentialAuthModel `tfsdk:"credentials_auth"`
+
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
 }
 
 type CredentialAuthModel struct {
@@ -90,6 +93,7 @@ func (r *ECRRegistryResource) Schema(ctx context.Context, req resource.SchemaReq
 					},
 				},
 			},
+			"adopt_existing": adoptExistingSchema("ECR registry"),
 		},
 	}
 }
@@ -100,18 +104,18 @@ func (r *ECRRegistryResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = workflow_pb.NewWorkflowManagerClient(conn)
+	r.client = clients.Workflow
 }
 
 func (r *ECRRegistryResource) refresh(ctx context.Context, diags diag.Diagnostics, data *ECRRegistryResourceModel) error {
@@ -165,6 +169,16 @@ func (r *ECRRegistryResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	if !data.AdoptExisting.ValueBool() {
+		_, err := r.client.GetContainerRegistryIntegration(ctx, &workflow_pb.GetContainerRegistryIntegrationReq{
+			RegistryName: data.Name.ValueString(),
+		})
+		if err := checkNotExists("ECR registry", data.Name.ValueString(), data.Name.ValueString(), err); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create ecr registry, got error: %s", err))
+			return
+		}
+	}
+
 	err := r.createOrUpdate(ctx, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create ecr registry, got error: %s", err))
@@ -245,3 +259,23 @@ func (r *ECRRegistryResource) Delete(ctx context.Context, req resource.DeleteReq
 
 	tflog.Trace(ctx, "deleted ecr registry resource")
 }
+
+func (r *ECRRegistryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var data ECRRegistryResourceModel
+
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the credentials cannot be read back, they are set on the next apply
+	data.Name = types.StringValue(req.ID)
+	err := r.refresh(ctx, resp.Diagnostics, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import ecr registry state for %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	// Save imported data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -3,14 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
-	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pkg/errors"
 	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
-	"golang.org/x/exp/maps"
-	"google.golang.org/grpc"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -25,17 +26,24 @@ type EcsRuntimeDataSource struct {
 	client env_pb.EnvironmentManagerClient
 }
 
+type EcsRuntimeDataSourceModel struct {
+	Name types.String `tfsdk:"name"`
+	Id   types.String `tfsdk:"id"`
+
+	Region        types.String `tfsdk:"region"`
+	AssumeRoleArn types.String `tfsdk:"assume_role_arn"`
+	ClusterArn    types.String `tfsdk:"cluster_arn"`
+
+	Labels types.List `tfsdk:"labels"`
+}
+
 func (d *EcsRuntimeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_ecs_runtime"
 }
 
 func (d *EcsRuntimeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
-	clusterTypes := maps.Values(env_pb.ClusterType_name)
-	sort.Strings(clusterTypes)
-
 	resp.Schema = schema.Schema{
-		// TODO(mike): expand description -- this feeds into the generated documentation that shows up in the registry
-		MarkdownDescription: "Prodvana Runtime",
+		MarkdownDescription: "Prodvana ECS Runtime",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Runtime name",
@@ -46,14 +54,6 @@ func (d *EcsRuntimeDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "Runtime identifier",
 				Computed:            true,
 			},
-			"access_key": schema.StringAttribute{
-				MarkdownDescription: "AWS Access Key ID with permissions to the ECS cluster",
-				Computed:            true,
-			},
-			"secret_key": schema.StringAttribute{
-				MarkdownDescription: "AWS Secret Key with permissions to the ECS cluster",
-				Computed:            true,
-			},
 			"region": schema.StringAttribute{
 				MarkdownDescription: "AWS Region of the ECS cluster",
 				Computed:            true,
@@ -66,6 +66,12 @@ func (d *EcsRuntimeDataSource) Schema(ctx context.Context, req datasource.Schema
 				MarkdownDescription: "ARN of the ECS cluster",
 				Computed:            true,
 			},
+			"labels": schema.ListNestedAttribute{
+				MarkdownDescription: "List of labels applied to the runtime",
+				Computed:            true,
+				Optional:            true,
+				NestedObject:        labels.LabelDefinitionNestedObjectDataSourceSchema(),
+			},
 		},
 	}
 }
@@ -76,22 +82,51 @@ func (d *EcsRuntimeDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = env_pb.NewEnvironmentManagerClient(conn)
+	d.client = clients.Environment
+}
+
+func (d *EcsRuntimeDataSource) read(ctx context.Context, diags diag.Diagnostics, data *EcsRuntimeDataSourceModel) error {
+	resp, err := d.client.GetCluster(ctx, &env_pb.GetClusterReq{
+		Runtime:     data.Name.ValueString(),
+		IncludeAuth: true,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read runtime state for %s", data.Name.ValueString())
+	}
+
+	if resp.Cluster.Type != env_pb.ClusterType_ECS {
+		return errors.Errorf("Unexpected non-ECS runtime type: %s", resp.Cluster.Type.String())
+	}
+
+	data.Name = types.StringValue(resp.Cluster.Name)
+	data.Id = types.StringValue(resp.Cluster.Id)
+	data.Labels = labels.LabelDefinitionsToTerraformList(ctx, resp.Cluster.Config.Labels, diags)
+
+	ecsAuth := resp.Cluster.GetAuth().GetEcs()
+	data.Region = types.StringValue(ecsAuth.GetRegion())
+	data.ClusterArn = types.StringValue(ecsAuth.GetClusterArn())
+	if ecsAuth.GetAssumeRoleArn() != "" {
+		data.AssumeRoleArn = types.StringValue(ecsAuth.GetAssumeRoleArn())
+	} else {
+		data.AssumeRoleArn = types.StringNull()
+	}
+
+	return nil
 }
 
 func (d *EcsRuntimeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data EcsRuntimeResourceModel
+	var data EcsRuntimeDataSourceModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -99,7 +134,7 @@ func (d *EcsRuntimeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	err := readEcsRuntimeData(ctx, d.client, &data)
+	err := d.read(ctx, resp.Diagnostics, &data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read runtime state for %s, got error: %s", data.Name.ValueString(), err))
 		return
@@ -7,16 +7,19 @@ import (
 	"github.com/pkg/errors"
 	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
 	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -34,11 +37,14 @@ type EcsRuntimeResource struct {
 	client env_pb.EnvironmentManagerClient
 }
 
-// RuntimeK8sResouceModel describes the resource data model.
+// EcsRuntimeResourceModel describes the resource data model.
 type EcsRuntimeResourceModel struct {
 	Name types.String `tfsdk:"name"`
 	Id   types.String `tfsdk:"id"`
 
+	Labels        types.List `tfsdk:"labels"`
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
+
 	AccessKey     types.String `tfsdk:"access_key"`
 	SecretKey     types.String `tfsdk:"secret_key"`
 	Region        types.String `tfsdk:"region"`
@@ -52,7 +58,7 @@ func (r *EcsRuntimeResource) Metadata(ctx context.Context, req resource.Metadata
 
 func (r *EcsRuntimeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "(Alpha! This feature is still in progress.) This resource allows you to manage a Prodvana ECS [Runtime](https://docs.prodvana.io/docs/prodvana-concepts#runtime).",
+		MarkdownDescription: "This resource allows you to manage a Prodvana ECS [Runtime](https://docs.prodvana.io/docs/prodvana-concepts#runtime). Authenticate with either static `access_key`/`secret_key` credentials, `assume_role_arn` on its own, or both.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Runtime name",
@@ -69,29 +75,38 @@ func (r *EcsRuntimeResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"agent_api_token": schema.StringAttribute{
+			"labels": schema.ListNestedAttribute{
+				MarkdownDescription: "List of labels to apply to the runtime",
 				Computed:            true,
-				MarkdownDescription: "API Token used for linking the Kubernetes Prodvana agent",
-				Sensitive:           true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+				Optional:            true,
+				NestedObject:        labels.LabelDefinitionNestedObjectResourceSchema(),
 			},
+			"adopt_existing": adoptExistingSchema("runtime"),
 			"access_key": schema.StringAttribute{
-				MarkdownDescription: "AWS Access Key ID with permissions to the ECS cluster",
-				Required:            true,
+				MarkdownDescription: "AWS Access Key ID with permissions to the ECS cluster. Must be set together with `secret_key`, unless `assume_role_arn` is used on its own.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("secret_key")),
+				},
 			},
 			"secret_key": schema.StringAttribute{
-				MarkdownDescription: "AWS Secret Key with permissions to the ECS cluster",
-				Required:            true,
+				MarkdownDescription: "AWS Secret Key with permissions to the ECS cluster. Must be set together with `access_key`, unless `assume_role_arn` is used on its own.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("access_key")),
+				},
 			},
 			"region": schema.StringAttribute{
 				MarkdownDescription: "AWS Region of the ECS cluster",
 				Required:            true,
 			},
 			"assume_role_arn": schema.StringAttribute{
-				MarkdownDescription: "AWS role to assume when accessing the ECS cluster",
+				MarkdownDescription: "AWS role to assume when accessing the ECS cluster. When set without `access_key`/`secret_key`, Prodvana assumes the role directly without static credentials.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("access_key")),
+				},
 			},
 			"cluster_arn": schema.StringAttribute{
 				MarkdownDescription: "ARN of the ECS cluster",
@@ -107,21 +122,21 @@ func (r *EcsRuntimeResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = env_pb.NewEnvironmentManagerClient(conn)
+	r.client = clients.Environment
 }
 
-func readEcsRuntimeData(ctx context.Context, client env_pb.EnvironmentManagerClient, data *EcsRuntimeResourceModel) error {
+func readEcsRuntimeData(ctx context.Context, diags diag.Diagnostics, client env_pb.EnvironmentManagerClient, data *EcsRuntimeResourceModel) error {
 	resp, err := client.GetCluster(ctx, &env_pb.GetClusterReq{
 		Runtime:     data.Name.ValueString(),
 		IncludeAuth: true,
@@ -130,31 +145,60 @@ func readEcsRuntimeData(ctx context.Context, client env_pb.EnvironmentManagerCli
 		return errors.Wrapf(err, "Unable to read runtime state for %s", data.Name.ValueString())
 	}
 
+	if resp.Cluster.Type != env_pb.ClusterType_ECS {
+		return errors.Errorf("Unexpected non-ECS runtime type: %s. Did the runtime change outside Terraform?", resp.Cluster.Type.String())
+	}
+
 	data.Name = types.StringValue(resp.Cluster.Name)
 	data.Id = types.StringValue(resp.Cluster.Id)
 
-	if resp.Cluster.Type != env_pb.ClusterType_ECS {
-		return errors.Errorf("Unexpected non-ECS runtime type: %s. Did the runtime change outside Terraform?", resp.Cluster.Type.String())
+	// access_key and secret_key are write-only from Terraform's perspective, so only
+	// the non-sensitive fields are read back to detect changes made outside Terraform
+	if ecsAuth := resp.Cluster.GetAuth().GetEcs(); ecsAuth != nil {
+		data.Region = types.StringValue(ecsAuth.Region)
+		data.ClusterArn = types.StringValue(ecsAuth.ClusterArn)
+		if ecsAuth.AssumeRoleArn != "" {
+			data.AssumeRoleArn = types.StringValue(ecsAuth.AssumeRoleArn)
+		} else {
+			data.AssumeRoleArn = types.StringNull()
+		}
 	}
+
+	tfLabels := types.ListNull(labels.LabelDefinitionObjectType)
+	if data.Labels.IsUnknown() || data.Labels.IsNull() {
+		tfLabels = labels.LabelDefinitionsToTerraformList(ctx, resp.Cluster.Config.Labels, diags)
+		if diags.HasError() {
+			return errors.Errorf("Failed to convert labels: %v", diags.Errors())
+		}
+	} else if !data.Labels.IsNull() {
+		userProvidedLabels := labels.LabelDefinitionsFromTerraformList(ctx, data.Labels, diags)
+		if diags.HasError() {
+			return errors.Errorf("Failed to convert labels: %v", diags.Errors())
+		}
+		tfLabels = labels.LabelDefinitionsToTerraformListWithValidation(ctx, resp.Cluster.Config.Labels, userProvidedLabels, diags)
+	}
+	data.Labels = tfLabels
 	return nil
 }
 
-func (r *EcsRuntimeResource) refresh(ctx context.Context, data *EcsRuntimeResourceModel) error {
-	return readEcsRuntimeData(ctx, r.client, data)
+func (r *EcsRuntimeResource) refresh(ctx context.Context, diags diag.Diagnostics, data *EcsRuntimeResourceModel) error {
+	return readEcsRuntimeData(ctx, diags, r.client, data)
 }
 
-func (r *EcsRuntimeResource) createOrUpdate(ctx context.Context, planData *EcsRuntimeResourceModel) error {
+func (r *EcsRuntimeResource) createOrUpdate(ctx context.Context, diags diag.Diagnostics, planData *EcsRuntimeResourceModel) error {
 	ecsAuth := &env_pb.ClusterAuth_ECSAuth{
-		AccessKey:  planData.AccessKey.ValueString(),
-		SecretKey:  planData.SecretKey.ValueString(),
 		Region:     planData.Region.ValueString(),
 		ClusterArn: planData.ClusterArn.ValueString(),
 	}
+	if !planData.AccessKey.IsNull() {
+		ecsAuth.AccessKey = planData.AccessKey.ValueString()
+		ecsAuth.SecretKey = planData.SecretKey.ValueString()
+	}
 	if !planData.AssumeRoleArn.IsNull() {
 		ecsAuth.AssumeRoleArn = planData.AssumeRoleArn.ValueString()
 	}
 
-	_, err := r.client.LinkCluster(ctx, &env_pb.LinkClusterReq{
+	linkResp, err := r.client.LinkCluster(ctx, &env_pb.LinkClusterReq{
 		Name: planData.Name.ValueString(),
 		Type: env_pb.ClusterType_ECS,
 		Auth: &env_pb.ClusterAuth{
@@ -167,8 +211,31 @@ func (r *EcsRuntimeResource) createOrUpdate(ctx context.Context, planData *EcsRu
 	if err != nil {
 		return err
 	}
+	planData.Id = types.StringValue(linkResp.ClusterId)
 
-	return r.refresh(ctx, planData)
+	getResp, err := r.client.GetCluster(ctx, &env_pb.GetClusterReq{
+		Runtime: planData.Name.ValueString(),
+	})
+	if err != nil {
+		return err
+	}
+
+	config := getResp.Cluster.Config
+	labelProtos := labels.LabelDefinitionProtosFromTerraformList(ctx, planData.Labels, diags)
+	if diags.HasError() {
+		return errors.Errorf("Failed to convert labels: %v", diags.Errors())
+	}
+	config.Labels = labelProtos
+
+	_, err = r.client.ConfigureCluster(ctx, &env_pb.ConfigureClusterReq{
+		RuntimeName: planData.Name.ValueString(),
+		Config:      config,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.refresh(ctx, diags, planData)
 }
 
 func (r *EcsRuntimeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -181,7 +248,17 @@ func (r *EcsRuntimeResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	err := r.createOrUpdate(ctx, data)
+	if !data.AdoptExisting.ValueBool() {
+		_, err := r.client.GetCluster(ctx, &env_pb.GetClusterReq{
+			Runtime: data.Name.ValueString(),
+		})
+		if err := checkNotExists("runtime", data.Name.ValueString(), data.Name.ValueString(), err); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create runtime, got error: %s", err))
+			return
+		}
+	}
+
+	err := r.createOrUpdate(ctx, resp.Diagnostics, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create runtime, got error: %s", err))
 		return
@@ -203,7 +280,7 @@ func (r *EcsRuntimeResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	err := r.refresh(ctx, data)
+	err := r.refresh(ctx, resp.Diagnostics, data)
 	if err != nil {
 		// if the runtime does not exist, remove the resource
 		if status.Code(err) == codes.NotFound {
@@ -230,7 +307,7 @@ func (r *EcsRuntimeResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	err := r.createOrUpdate(ctx, planData)
+	err := r.createOrUpdate(ctx, resp.Diagnostics, planData)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update runtime, got error: %s", err))
 		return
@@ -270,7 +347,7 @@ func (r *EcsRuntimeResource) ImportState(ctx context.Context, req resource.Impor
 	}
 
 	data.Name = types.StringValue(req.ID)
-	err := r.refresh(ctx, &data)
+	err := r.refresh(ctx, resp.Diagnostics, &data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import runtime state for %s, got error: %s", data.Name.ValueString(), err))
 		return
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEcsRuntimeResource(t *testing.T) {
+	runtimeName := uniqueTestName("ecs-runtime-tests")
+	clusterArn := testAccGetenv("ECS_CLUSTER_ARN", "arn:aws:ecs:us-west-2:000000000000:cluster/fake")
+	roleArn := testAccGetenv("ECS_ASSUME_ROLE_ARN", "arn:aws:iam::000000000000:role/fake")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEcsRuntimeResourceConfig(runtimeName, "us-west-2", clusterArn, roleArn, "staging"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "name", runtimeName),
+					resource.TestCheckResourceAttrSet("prodvana_ecs_runtime.test", "id"),
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "region", "us-west-2"),
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "cluster_arn", clusterArn),
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "assume_role_arn", roleArn),
+					resource.TestCheckNoResourceAttr("prodvana_ecs_runtime.test", "access_key"),
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "labels.0.label", "env"),
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "labels.0.value", "staging"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "prodvana_ecs_runtime.test",
+				ImportStateId:     runtimeName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccEcsRuntimeResourceConfig(runtimeName, "us-west-2", clusterArn, roleArn, "prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "name", runtimeName),
+					resource.TestCheckResourceAttrSet("prodvana_ecs_runtime.test", "id"),
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "labels.0.label", "env"),
+					resource.TestCheckResourceAttr("prodvana_ecs_runtime.test", "labels.0.value", "prod"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccEcsRuntimeResourceConfig(name, region, clusterArn, roleArn, env string) string {
+	return fmt.Sprintf(`
+resource "prodvana_ecs_runtime" "test" {
+  name = %[1]q
+  region = %[2]q
+  cluster_arn = %[3]q
+  assume_role_arn = %[4]q
+  labels = [
+    {
+      label = "env"
+      value = %[5]q
+    },
+  ]
+}
+`, name, region, clusterArn, roleArn, env)
+}
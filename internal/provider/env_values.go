@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+)
+
+type envValue struct {
+	Value            types.String         `tfsdk:"value"`
+	Secret           *envSecret           `tfsdk:"secret"`
+	KubernetesSecret *envKubernetesSecret `tfsdk:"kubernetes_secret"`
+}
+type envSecret struct {
+	Key     types.String `tfsdk:"key"`
+	Version types.String `tfsdk:"version"`
+}
+type envKubernetesSecret struct {
+	SecretName types.String `tfsdk:"secret_name"`
+	Key        types.String `tfsdk:"key"`
+}
+
+// envValueNestedObjectSchema is the schema for a single environment variable
+// value, shared by release channel policies and service configs.
+func envValueNestedObjectSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"value": schema.StringAttribute{
+				MarkdownDescription: "Non-sensitive environment variable value",
+				Optional:            true,
+			},
+			"secret": schema.SingleNestedAttribute{
+				MarkdownDescription: "Reference to a secret value stored in Prodvana.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"key": schema.StringAttribute{
+						MarkdownDescription: "Name of the secret.",
+						Optional:            true,
+					},
+					"version": schema.StringAttribute{
+						MarkdownDescription: "Version of the secret",
+						Optional:            true,
+					},
+				},
+			},
+			"kubernetes_secret": schema.SingleNestedAttribute{
+				MarkdownDescription: "Reference to a secret value stored in Kubernetes.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"secret_name": schema.StringAttribute{
+						MarkdownDescription: "Name of the secret object",
+						Optional:            true,
+					},
+					"key": schema.StringAttribute{
+						MarkdownDescription: "Key of the secret in the data field of the secret object",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func envValuesToProtos(env map[string]*envValue) (map[string]*common_config_pb.EnvValue, error) {
+	protos := map[string]*common_config_pb.EnvValue{}
+	for k, v := range env {
+		envVal := &common_config_pb.EnvValue{}
+
+		setOneofs := 0
+		if !v.Value.IsNull() {
+			setOneofs++
+		}
+		if v.Secret != nil {
+			setOneofs++
+		}
+		if v.KubernetesSecret != nil {
+			setOneofs++
+		}
+		if setOneofs > 1 {
+			return nil, fmt.Errorf("only one of Value or Secret or KubernetesSecret can be set for %s", k)
+		}
+
+		if !v.Value.IsNull() {
+			envVal.ValueOneof = &common_config_pb.EnvValue_Value{
+				Value: v.Value.ValueString(),
+			}
+		} else if v.Secret != nil {
+			envVal.ValueOneof = &common_config_pb.EnvValue_Secret{
+				Secret: &common_config_pb.Secret{
+					Key:     v.Secret.Key.ValueString(),
+					Version: v.Secret.Version.ValueString(),
+				},
+			}
+		} else if v.KubernetesSecret != nil {
+			envVal.ValueOneof = &common_config_pb.EnvValue_KubernetesSecret{
+				KubernetesSecret: &common_config_pb.KubernetesSecret{
+					Key:        v.KubernetesSecret.Key.ValueString(),
+					SecretName: v.KubernetesSecret.SecretName.ValueString(),
+				},
+			}
+		} else {
+			return nil, fmt.Errorf("EnvValue for %s is empty", k)
+		}
+		protos[k] = envVal
+	}
+	return protos, nil
+}
+
+func envValuesFromProtos(env map[string]*common_config_pb.EnvValue) map[string]*envValue {
+	values := make(map[string]*envValue, len(env))
+	for k, v := range env {
+		envVal := &envValue{}
+		switch t := v.ValueOneof.(type) {
+		case *common_config_pb.EnvValue_Value:
+			envVal.Value = types.StringValue(t.Value)
+		case *common_config_pb.EnvValue_Secret:
+			envVal.Secret = &envSecret{
+				Key:     types.StringValue(t.Secret.Key),
+				Version: types.StringValue(t.Secret.Version),
+			}
+		case *common_config_pb.EnvValue_KubernetesSecret:
+			envVal.KubernetesSecret = &envKubernetesSecret{
+				Key:        types.StringValue(t.KubernetesSecret.Key),
+				SecretName: types.StringValue(t.KubernetesSecret.SecretName),
+			}
+		}
+		values[k] = envVal
+	}
+	return values
+}
@@ -0,0 +1,182 @@
+package fakeserver
+
+import (
+	"context"
+	"sort"
+
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
+	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	svc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type applicationManager struct {
+	app_pb.UnimplementedApplicationManagerServer
+	store *store
+}
+
+// ConfigureApplication upserts the application. The release channels in the
+// config replace the application's existing ones, so callers that only want
+// to change application level fields must send back what GetApplication
+// returned.
+func (m *applicationManager) ConfigureApplication(ctx context.Context, req *app_pb.ConfigureApplicationReq) (*app_pb.ConfigureApplicationResp, error) {
+	config := req.ApplicationConfig
+	if config == nil || config.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "application name is required")
+	}
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, ok := m.store.applications[config.Name]
+	if ok && req.BaseVersion != "" && req.BaseVersion != app.Meta.Version {
+		return nil, status.Errorf(codes.FailedPrecondition, "application %s is at version %s, not base version %s", config.Name, app.Meta.Version, req.BaseVersion)
+	}
+	for _, rc := range config.ReleaseChannels {
+		if err := m.store.validateReleaseChannel(config.Name, rc); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		app = &app_pb.Application{
+			Meta: &object.ObjectMeta{
+				Id:   m.store.newId(),
+				Name: config.Name,
+			},
+			UserMetadata: &app_pb.ApplicationUserMetadata{},
+		}
+		m.store.applications[config.Name] = app
+		m.store.releaseChannels[config.Name] = map[string]*rc_pb.ReleaseChannel{}
+		m.store.services[config.Name] = map[string]*svc_pb.Service{}
+	}
+
+	newConfig := proto.Clone(config).(*app_pb.ApplicationConfig)
+	newConfig.ReleaseChannels = nil
+	changed := !ok || req.ForceCreateNewVersion || !proto.Equal(app.Config, newConfig)
+
+	existing := m.store.releaseChannels[config.Name]
+	desired := map[string]bool{}
+	for _, rc := range config.ReleaseChannels {
+		desired[rc.Name] = true
+		if m.store.upsertReleaseChannel(config.Name, rc) {
+			changed = true
+		}
+	}
+	for name := range existing {
+		if !desired[name] {
+			delete(existing, name)
+			changed = true
+		}
+	}
+
+	app.Config = newConfig
+	app.Meta.Source = req.Source
+	app.Meta.SourceMetadata = req.SourceMetadata
+	if changed {
+		app.Meta.Version = m.store.newVersion()
+	}
+	return &app_pb.ConfigureApplicationResp{
+		Meta:              proto.Clone(app.Meta).(*object.ObjectMeta),
+		CreatedNewVersion: changed,
+	}, nil
+}
+
+func (m *applicationManager) GetApplication(ctx context.Context, req *app_pb.GetApplicationReq) (*app_pb.GetApplicationResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	return &app_pb.GetApplicationResp{
+		Application: m.store.applicationWithReleaseChannels(app),
+	}, nil
+}
+
+func (m *applicationManager) ListApplications(ctx context.Context, req *app_pb.ListApplicationsReq) (*app_pb.ListApplicationsResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	names := make([]string, 0, len(m.store.applications))
+	for name := range m.store.applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resp := &app_pb.ListApplicationsResp{}
+	for _, name := range names {
+		resp.Applications = append(resp.Applications, m.store.applicationWithReleaseChannels(m.store.applications[name]))
+	}
+	return resp, nil
+}
+
+func (m *applicationManager) DeleteApplication(ctx context.Context, req *app_pb.DeleteApplicationReq) (*app_pb.DeleteApplicationResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	delete(m.store.applications, app.Meta.Name)
+	delete(m.store.releaseChannels, app.Meta.Name)
+	for _, svc := range m.store.services[app.Meta.Name] {
+		delete(m.store.serviceVersions, svc.Meta.Id)
+	}
+	delete(m.store.services, app.Meta.Name)
+	return &app_pb.DeleteApplicationResp{}, nil
+}
+
+func (m *applicationManager) GetApplicationMetadata(ctx context.Context, req *app_pb.GetApplicationMetadataReq) (*app_pb.GetApplicationMetadataResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	return &app_pb.GetApplicationMetadataResp{
+		Metadata: proto.Clone(app.UserMetadata).(*app_pb.ApplicationUserMetadata),
+	}, nil
+}
+
+func (m *applicationManager) SetApplicationMetadata(ctx context.Context, req *app_pb.SetApplicationMetadataReq) (*app_pb.SetApplicationMetadataResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &app_pb.ApplicationUserMetadata{}
+	if req.Metadata != nil {
+		metadata = proto.Clone(req.Metadata).(*app_pb.ApplicationUserMetadata)
+	}
+	app.UserMetadata = metadata
+	return &app_pb.SetApplicationMetadataResp{}, nil
+}
+
+// application finds an application by name or id, must be called with mu held.
+func (s *store) application(nameOrId string) (*app_pb.Application, error) {
+	if app, ok := s.applications[nameOrId]; ok {
+		return app, nil
+	}
+	for _, app := range s.applications {
+		if app.Meta.Id == nameOrId {
+			return app, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "application %s not found", nameOrId)
+}
+
+// applicationWithReleaseChannels returns a copy of app with its release
+// channels filled in from the store, must be called with mu held.
+func (s *store) applicationWithReleaseChannels(app *app_pb.Application) *app_pb.Application {
+	out := proto.Clone(app).(*app_pb.Application)
+	for _, rc := range s.sortedReleaseChannels(app.Meta.Name) {
+		out.Config.ReleaseChannels = append(out.Config.ReleaseChannels, proto.Clone(rc.Config).(*rc_pb.ReleaseChannelConfig))
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+package fakeserver
+
+import (
+	"context"
+
+	ds_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state"
+	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type desiredStateManager struct {
+	ds_pb.UnimplementedDesiredStateManagerServer
+	store *store
+}
+
+// desiredState is a service instance desired state. It reports CONVERGING on
+// the first summary read and settles on the next one, so callers exercise
+// their polling loop.
+type desiredState struct {
+	state    *model.ServiceInstanceState
+	status   model.Status
+	polled   bool
+	failWith string
+	created  *timestamppb.Timestamp
+}
+
+// SetConvergenceFailure makes every desired state set for the service from
+// now on fail with message instead of converging. An empty message restores
+// the default behavior.
+func (s *Server) SetConvergenceFailure(application, service, message string) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	if message == "" {
+		delete(s.store.convergenceFailures, application+"/"+service)
+		return
+	}
+	s.store.convergenceFailures[application+"/"+service] = message
+}
+
+func (m *desiredStateManager) SetDesiredState(ctx context.Context, req *ds_pb.SetDesiredStateReq) (*ds_pb.SetDesiredStateResp, error) {
+	var instances []*model.ServiceInstanceState
+	switch state := req.DesiredState.GetStateOneof().(type) {
+	case *model.State_ServiceInstance:
+		instances = append(instances, state.ServiceInstance)
+	case *model.State_Service:
+		for _, instance := range state.Service.ReleaseChannels {
+			instance = proto.Clone(instance).(*model.ServiceInstanceState)
+			instance.Application = state.Service.Application
+			instance.Service = state.Service.Service
+			instances = append(instances, instance)
+		}
+	default:
+		return nil, status.Error(codes.Unimplemented, "only service and service instance desired states are supported")
+	}
+	if len(instances) != 1 {
+		return nil, status.Error(codes.Unimplemented, "exactly one release channel must be set")
+	}
+	instance := proto.Clone(instances[0]).(*model.ServiceInstanceState)
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	svc, err := m.store.service(instance.Application, instance.Service)
+	if err != nil {
+		return nil, err
+	}
+	rc, ok := m.store.releaseChannels[svc.Config.Application][instance.ReleaseChannel]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "release channel %s not found", instance.ReleaseChannel)
+	}
+	if len(instance.Versions) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one version is required")
+	}
+	for _, v := range instance.Versions {
+		if _, ok := m.store.serviceVersions[svc.Meta.Id][v.Version]; !ok {
+			return nil, status.Errorf(codes.NotFound, "version %s of service %s not found", v.Version, svc.Meta.Name)
+		}
+	}
+	instance.Application = svc.Config.Application
+	instance.ServiceId = svc.Meta.Id
+	instance.ReleaseChannelId = rc.Meta.Id
+
+	for _, existing := range m.store.desiredStates {
+		if existing.state.ServiceId == instance.ServiceId && existing.state.ReleaseChannelId == instance.ReleaseChannelId && existing.status == model.Status_CONVERGING {
+			existing.status = model.Status_REPLACED
+		}
+	}
+
+	id := m.store.newId()
+	instance.Meta = &model.Metadata{
+		DesiredStateId:     id,
+		RootDesiredStateId: id,
+	}
+	m.store.desiredStates[id] = &desiredState{
+		state:    instance,
+		status:   model.Status_CONVERGING,
+		failWith: m.store.convergenceFailures[svc.Config.Application+"/"+svc.Meta.Name],
+		created:  timestamppb.Now(),
+	}
+	return &ds_pb.SetDesiredStateResp{
+		IdOneof: &ds_pb.SetDesiredStateResp_DesiredStateId{
+			DesiredStateId: id,
+		},
+	}, nil
+}
+
+func (m *desiredStateManager) GetDesiredStateConvergenceSummary(ctx context.Context, req *ds_pb.GetDesiredStateConvergenceReq) (*ds_pb.GetDesiredStateConvergenceSummaryResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	ds, ok := m.store.desiredStates[req.DesiredStateId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "desired state %s not found", req.DesiredStateId)
+	}
+	if ds.status == model.Status_CONVERGING {
+		if ds.polled {
+			ds.status = model.Status_CONVERGED
+			if ds.failWith != "" {
+				ds.status = model.Status_FAILED
+			}
+		}
+		ds.polled = true
+	}
+
+	state := &model.State{
+		StateOneof: &model.State_ServiceInstance{
+			ServiceInstance: proto.Clone(ds.state).(*model.ServiceInstanceState),
+		},
+	}
+	summary := &ds_pb.DesiredStateSummary{
+		InputDesiredState: state,
+		DesiredState:      state,
+		Status:            ds.status,
+		CreationTimestamp: ds.created,
+	}
+	if ds.status == model.Status_FAILED {
+		summary.StatusExplanations = map[string]*ds_pb.StatusExplanations{
+			req.DesiredStateId: {
+				StatusExplanations: []*model.StatusExplanation{
+					{
+						DesiredStateId: req.DesiredStateId,
+						Message:        ds.failWith,
+					},
+				},
+			},
+		}
+	}
+	return &ds_pb.GetDesiredStateConvergenceSummaryResp{
+		Summary: summary,
+	}, nil
+}
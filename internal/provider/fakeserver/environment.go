@@ -0,0 +1,170 @@
+package fakeserver
+
+import (
+	"context"
+	"fmt"
+
+	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type environmentManager struct {
+	env_pb.UnimplementedEnvironmentManagerServer
+	store *store
+}
+
+// LinkCluster creates the runtime if it does not exist yet, otherwise it
+// replaces its auth and type, matching the upsert semantics of the real API.
+func (m *environmentManager) LinkCluster(ctx context.Context, req *env_pb.LinkClusterReq) (*env_pb.LinkClusterResp, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	c, ok := m.store.clusters[req.Name]
+	if !ok {
+		c = &cluster{
+			info: &env_pb.ListClustersResp_ClusterInfo{
+				Name: req.Name,
+				Id:   m.store.newId(),
+				Config: &env_pb.ClusterConfig{
+					Name: req.Name,
+				},
+			},
+			agentApiToken: m.store.newToken(),
+		}
+		m.store.clusters[req.Name] = c
+	}
+	c.info.Type = req.Type
+	c.info.Auth = proto.Clone(req.Auth).(*env_pb.ClusterAuth)
+	if req.Config != nil {
+		c.info.Config = proto.Clone(req.Config).(*env_pb.ClusterConfig)
+		c.info.Config.Name = req.Name
+	}
+
+	resp := &env_pb.LinkClusterResp{
+		Success:   true,
+		ClusterId: c.info.Id,
+	}
+	if c.info.Type == env_pb.ClusterType_K8S {
+		agentURL := fmt.Sprintf("api.%s.%s", OrgSlug, serverName)
+		resp.K8SAgentUrl = agentURL
+		resp.K8SAgentImage = "prodvana/agent:fake"
+		resp.K8SAgentApiToken = c.agentApiToken
+		resp.K8SAgentArgs = []string{
+			"/agent",
+			"--clusterid", c.info.Id,
+			"--auth", c.agentApiToken,
+			"--server-addr", agentURL,
+		}
+	}
+	return resp, nil
+}
+
+func (m *environmentManager) ListClusters(ctx context.Context, req *env_pb.ListClustersReq) (*env_pb.ListClustersResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	resp := &env_pb.ListClustersResp{}
+	for _, c := range m.store.clusters {
+		resp.Clusters = append(resp.Clusters, m.clusterInfo(c, false))
+	}
+	return resp, nil
+}
+
+func (m *environmentManager) GetCluster(ctx context.Context, req *env_pb.GetClusterReq) (*env_pb.GetClusterResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	c, err := m.lookup(req.Runtime)
+	if err != nil {
+		return nil, err
+	}
+	return &env_pb.GetClusterResp{
+		Cluster: m.clusterInfo(c, req.IncludeAuth),
+	}, nil
+}
+
+func (m *environmentManager) RemoveCluster(ctx context.Context, req *env_pb.RemoveClusterReq) (*env_pb.RemoveClusterResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if _, ok := m.store.clusters[req.Name]; !ok {
+		return nil, status.Errorf(codes.NotFound, "runtime %s not found", req.Name)
+	}
+	delete(m.store.clusters, req.Name)
+	return &env_pb.RemoveClusterResp{}, nil
+}
+
+func (m *environmentManager) ConfigureCluster(ctx context.Context, req *env_pb.ConfigureClusterReq) (*env_pb.ConfigureClusterResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	c, ok := m.store.clusters[req.RuntimeName]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "runtime %s not found", req.RuntimeName)
+	}
+	config := &env_pb.ClusterConfig{}
+	if req.Config != nil {
+		config = proto.Clone(req.Config).(*env_pb.ClusterConfig)
+	}
+	config.Name = req.RuntimeName
+	c.info.Config = config
+	return &env_pb.ConfigureClusterResp{}, nil
+}
+
+func (m *environmentManager) GetClusterStatus(ctx context.Context, req *env_pb.GetClusterStatusReq) (*env_pb.GetClusterStatusResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	c, err := m.lookup(req.ClusterId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &env_pb.GetClusterStatusResp{}
+	if c.agentConnected {
+		resp.LastHeartbeatTimestamp = timestamppb.Now()
+	}
+	return resp, nil
+}
+
+func (m *environmentManager) GetClusterAgentApiToken(ctx context.Context, req *env_pb.GetClusterAgentApiTokenReq) (*env_pb.GetClusterAgentApiTokenResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	c, ok := m.store.clusters[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "runtime %s not found", req.Name)
+	}
+	return &env_pb.GetClusterAgentApiTokenResp{
+		ApiToken: c.agentApiToken,
+	}, nil
+}
+
+// lookup finds a runtime by name or id, must be called with mu held.
+func (m *environmentManager) lookup(nameOrId string) (*cluster, error) {
+	if c, ok := m.store.clusters[nameOrId]; ok {
+		return c, nil
+	}
+	for _, c := range m.store.clusters {
+		if c.info.Id == nameOrId {
+			return c, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "runtime %s not found", nameOrId)
+}
+
+func (m *environmentManager) clusterInfo(c *cluster, includeAuth bool) *env_pb.ListClustersResp_ClusterInfo {
+	info := proto.Clone(c.info).(*env_pb.ListClustersResp_ClusterInfo)
+	if !includeAuth {
+		info.Auth = nil
+	}
+	if c.agentConnected {
+		info.LastHeartbeatTimestamp = timestamppb.Now()
+	}
+	return info
+}
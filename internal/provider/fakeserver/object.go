@@ -0,0 +1,80 @@
+package fakeserver
+
+import (
+	"context"
+
+	labels_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/labels"
+	obj_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type objectManager struct {
+	obj_pb.UnimplementedObjectManagerServer
+	store *store
+}
+
+func (m *objectManager) GetLabels(ctx context.Context, req *obj_pb.GetLabelsReq) (*obj_pb.GetLabelsResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	var labels []*labels_pb.LabelDefinition
+	switch req.Type {
+	case obj_pb.ObjectType_APPLICATION:
+		app, err := m.store.application(req.Id)
+		if err != nil {
+			return nil, err
+		}
+		labels = m.store.applicationLabels[app.Meta.Name]
+	case obj_pb.ObjectType_RUNTIME:
+		found := false
+		for _, c := range m.store.clusters {
+			if c.info.Id == req.Id {
+				labels = c.info.Config.GetLabels()
+				found = true
+			}
+		}
+		if !found {
+			return nil, status.Errorf(codes.NotFound, "runtime %s not found", req.Id)
+		}
+	case obj_pb.ObjectType_RELEASE_CHANNEL:
+		found := false
+		for _, rcs := range m.store.releaseChannels {
+			for _, rc := range rcs {
+				if rc.Meta.Id == req.Id {
+					labels = rc.Config.GetLabels()
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, status.Errorf(codes.NotFound, "release channel %s not found", req.Id)
+		}
+	default:
+		return nil, status.Errorf(codes.Unimplemented, "labels for %s objects are not supported", req.Type)
+	}
+
+	resp := &obj_pb.GetLabelsResp{}
+	for _, label := range labels {
+		resp.Labels = append(resp.Labels, proto.Clone(label).(*labels_pb.LabelDefinition))
+	}
+	return resp, nil
+}
+
+// SetApplicationLabels sets the labels GetLabels returns for an application,
+// the application config itself has no labels to set them through.
+func (s *Server) SetApplicationLabels(application string, labels map[string]string) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if len(labels) == 0 {
+		delete(s.store.applicationLabels, application)
+		return
+	}
+	defs := make([]*labels_pb.LabelDefinition, 0, len(labels))
+	for label, value := range labels {
+		defs = append(defs, &labels_pb.LabelDefinition{Label: label, Value: value})
+	}
+	s.store.applicationLabels[application] = defs
+}
@@ -0,0 +1,120 @@
+package fakeserver
+
+import (
+	"context"
+	"sort"
+
+	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type protectionManager struct {
+	prot_pb.UnimplementedProtectionManagerServer
+	store *store
+}
+
+func (m *protectionManager) ConfigureProtection(ctx context.Context, req *prot_pb.ConfigureProtectionReq) (*prot_pb.ConfigureProtectionResp, error) {
+	config := req.ProtectionConfig
+	if config == nil || config.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "protection name is required")
+	}
+	if config.ExecConfig == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "protection %s must set task_config or kubernetes_config", config.Name)
+	}
+	seen := map[string]bool{}
+	for _, param := range config.Parameters {
+		if seen[param.Name] {
+			return nil, status.Errorf(codes.InvalidArgument, "protection %s declares parameter %s more than once", config.Name, param.Name)
+		}
+		seen[param.Name] = true
+	}
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	config = proto.Clone(config).(*prot_pb.ProtectionConfig)
+	protection, ok := m.store.protections[config.Name]
+	changed := !ok || !proto.Equal(protection.Config, config)
+	if !ok {
+		protection = &prot_pb.Protection{
+			Meta: &object.ObjectMeta{
+				Id:   m.store.newId(),
+				Name: config.Name,
+			},
+		}
+		m.store.protections[config.Name] = protection
+	}
+	protection.Config = config
+	protection.Meta.Source = req.Source
+	protection.Meta.SourceMetadata = req.SourceMetadata
+	if changed {
+		protection.Meta.Version = m.store.newVersion()
+	}
+	return &prot_pb.ConfigureProtectionResp{
+		ProtectionId: protection.Meta.Id,
+		Version:      protection.Meta.Version,
+	}, nil
+}
+
+func (m *protectionManager) GetProtection(ctx context.Context, req *prot_pb.GetProtectionReq) (*prot_pb.GetProtectionResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	protection, err := m.store.protection(req.Protection)
+	if err != nil {
+		return nil, err
+	}
+	return &prot_pb.GetProtectionResp{
+		Protection: proto.Clone(protection).(*prot_pb.Protection),
+	}, nil
+}
+
+// GetProtectionConfig only knows about the latest version of each protection.
+func (m *protectionManager) GetProtectionConfig(ctx context.Context, req *prot_pb.GetProtectionConfigReq) (*prot_pb.GetProtectionConfigResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	protection, err := m.store.protection(req.Protection)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != "" && req.Version != protection.Meta.Version {
+		return nil, status.Errorf(codes.NotFound, "version %s of protection %s not found", req.Version, req.Protection)
+	}
+	return &prot_pb.GetProtectionConfigResp{
+		InputConfig:    proto.Clone(protection.Config).(*prot_pb.ProtectionConfig),
+		CompiledConfig: proto.Clone(protection.Config).(*prot_pb.ProtectionConfig),
+		Version:        protection.Meta.Version,
+	}, nil
+}
+
+func (m *protectionManager) ListProtections(ctx context.Context, req *prot_pb.ListProtectionsReq) (*prot_pb.ListProtectionsResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	names := make([]string, 0, len(m.store.protections))
+	for name := range m.store.protections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resp := &prot_pb.ListProtectionsResp{}
+	for _, name := range names {
+		resp.Protections = append(resp.Protections, proto.Clone(m.store.protections[name]).(*prot_pb.Protection))
+	}
+	return resp, nil
+}
+
+// protection finds a protection by name or id, must be called with mu held.
+func (s *store) protection(nameOrId string) (*prot_pb.Protection, error) {
+	if protection, ok := s.protections[nameOrId]; ok {
+		return protection, nil
+	}
+	for _, protection := range s.protections {
+		if protection.Meta.Id == nameOrId {
+			return protection, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "protection %s not found", nameOrId)
+}
@@ -0,0 +1,205 @@
+package fakeserver
+
+import (
+	"context"
+	"sort"
+
+	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
+	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type releaseChannelManager struct {
+	rc_pb.UnimplementedReleaseChannelManagerServer
+	store *store
+}
+
+func (m *releaseChannelManager) ConfigureReleaseChannel(ctx context.Context, req *rc_pb.ConfigureReleaseChannelReq) (*rc_pb.ConfigureReleaseChannelResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.validateReleaseChannel(app.Meta.Name, req.ReleaseChannel); err != nil {
+		return nil, err
+	}
+	changed := m.store.upsertReleaseChannel(app.Meta.Name, req.ReleaseChannel)
+	if changed || req.ForceCreateNewVersion {
+		app.Meta.Version = m.store.newVersion()
+	}
+	rc := m.store.releaseChannels[app.Meta.Name][req.ReleaseChannel.Name]
+	return &rc_pb.ConfigureReleaseChannelResp{
+		Version:           rc.Meta.Version,
+		CreatedNewVersion: changed,
+	}, nil
+}
+
+func (m *releaseChannelManager) GetReleaseChannel(ctx context.Context, req *rc_pb.GetReleaseChannelReq) (*rc_pb.GetReleaseChannelResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	rc, err := m.store.releaseChannel(req.Application, req.ReleaseChannel)
+	if err != nil {
+		return nil, err
+	}
+	return &rc_pb.GetReleaseChannelResp{
+		ReleaseChannel: proto.Clone(rc).(*rc_pb.ReleaseChannel),
+	}, nil
+}
+
+func (m *releaseChannelManager) GetReleaseChannelConfig(ctx context.Context, req *rc_pb.GetReleaseChannelConfigReq) (*rc_pb.GetReleaseChannelConfigResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	rc, err := m.store.releaseChannel(req.Application, req.ReleaseChannel)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != "" && req.Version != rc.Meta.Version {
+		return nil, status.Errorf(codes.NotFound, "version %s of release channel %s not found", req.Version, req.ReleaseChannel)
+	}
+	return &rc_pb.GetReleaseChannelConfigResp{
+		Config:         proto.Clone(rc.Config).(*rc_pb.ReleaseChannelConfig),
+		Version:        rc.Meta.Version,
+		CompiledConfig: proto.Clone(rc.Config).(*rc_pb.ReleaseChannelConfig),
+	}, nil
+}
+
+func (m *releaseChannelManager) ListReleaseChannels(ctx context.Context, req *rc_pb.ListReleaseChannelsReq) (*rc_pb.ListReleaseChannelsResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	resp := &rc_pb.ListReleaseChannelsResp{}
+	for _, rc := range m.store.sortedReleaseChannels(app.Meta.Name) {
+		resp.ReleaseChannels = append(resp.ReleaseChannels, proto.Clone(rc).(*rc_pb.ReleaseChannel))
+	}
+	return resp, nil
+}
+
+func (m *releaseChannelManager) DeleteReleaseChannel(ctx context.Context, req *rc_pb.DeleteReleaseChannelReq) (*rc_pb.DeleteReleaseChannelResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	rc, err := m.store.releaseChannel(req.Application, req.ReleaseChannel)
+	if err != nil {
+		return nil, err
+	}
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	delete(m.store.releaseChannels[app.Meta.Name], rc.Meta.Name)
+	app.Meta.Version = m.store.newVersion()
+	return &rc_pb.DeleteReleaseChannelResp{}, nil
+}
+
+// releaseChannel finds a release channel by application and name, must be
+// called with mu held.
+func (s *store) releaseChannel(application, name string) (*rc_pb.ReleaseChannel, error) {
+	app, err := s.application(application)
+	if err != nil {
+		return nil, err
+	}
+	rc, ok := s.releaseChannels[app.Meta.Name][name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "release channel %s not found in application %s", name, application)
+	}
+	return rc, nil
+}
+
+// sortedReleaseChannels must be called with mu held.
+func (s *store) sortedReleaseChannels(application string) []*rc_pb.ReleaseChannel {
+	rcs := make([]*rc_pb.ReleaseChannel, 0, len(s.releaseChannels[application]))
+	for _, rc := range s.releaseChannels[application] {
+		rcs = append(rcs, rc)
+	}
+	sort.Slice(rcs, func(i, j int) bool {
+		return rcs[i].Meta.Name < rcs[j].Meta.Name
+	})
+	return rcs
+}
+
+// validateReleaseChannel must be called with mu held.
+func (s *store) validateReleaseChannel(application string, config *rc_pb.ReleaseChannelConfig) error {
+	if config == nil || config.Name == "" {
+		return status.Error(codes.InvalidArgument, "release channel name is required")
+	}
+	names := map[string]bool{}
+	for _, rt := range config.Runtimes {
+		if rt.Runtime != "" {
+			if _, ok := s.clusters[rt.Runtime]; !ok {
+				return status.Errorf(codes.InvalidArgument, "release channel %s references unknown runtime %s", config.Name, rt.Runtime)
+			}
+		}
+		name := rt.Name
+		if name == "" {
+			name = rt.Runtime
+		}
+		if names[name] {
+			return status.Errorf(codes.InvalidArgument, "release channel %s has duplicate runtime connection %s", config.Name, name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// upsertReleaseChannel stores config with server-side defaults applied and
+// reports whether anything changed. Must be called with mu held, after
+// validateReleaseChannel.
+func (s *store) upsertReleaseChannel(application string, config *rc_pb.ReleaseChannelConfig) bool {
+	config = proto.Clone(config).(*rc_pb.ReleaseChannelConfig)
+	for _, rt := range config.Runtimes {
+		if rt.Name == "" {
+			rt.Name = rt.Runtime
+		}
+		if rt.Type == rc_pb.RuntimeConnectionType_UNKNOWN_CONNECTION {
+			if c, ok := s.clusters[rt.Runtime]; ok {
+				switch c.info.Type {
+				case env_pb.ClusterType_K8S, env_pb.ClusterType_ECS:
+					rt.Type = rc_pb.RuntimeConnectionType_LONG_LIVED_COMPUTE
+				case env_pb.ClusterType_EXTENSION:
+					rt.Type = rc_pb.RuntimeConnectionType_EXTENSION
+				}
+			}
+		}
+	}
+
+	for _, attachments := range [][]*prot_pb.ProtectionAttachmentConfig{
+		config.Protections,
+		config.ConvergenceProtections,
+		config.ServiceInstanceProtections,
+	} {
+		for _, pa := range attachments {
+			if pa.Name == "" && pa.Ref != nil {
+				pa.Name = pa.Ref.Name
+			}
+		}
+	}
+
+	existing, ok := s.releaseChannels[application][config.Name]
+	if ok && proto.Equal(existing.Config, config) {
+		return false
+	}
+	if !ok {
+		existing = &rc_pb.ReleaseChannel{
+			Meta: &object.ObjectMeta{
+				Id:   s.newId(),
+				Name: config.Name,
+			},
+		}
+		s.releaseChannels[application][config.Name] = existing
+	}
+	existing.Config = config
+	existing.Meta.Version = s.newVersion()
+	return true
+}
@@ -0,0 +1,144 @@
+package fakeserver
+
+import (
+	"context"
+	"sort"
+
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+	secrets_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/secrets"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type secretsManager struct {
+	secrets_pb.UnimplementedSecretsManagerServer
+	store *store
+}
+
+// secretVersion is a single value written to a secret, versions of a secret
+// are kept oldest first.
+type secretVersion struct {
+	version string
+	value   string
+}
+
+func (m *secretsManager) ListSecrets(ctx context.Context, req *secrets_pb.ListSecretsReq) (*secrets_pb.ListSecretsResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	keys := make([]string, 0, len(m.store.secrets))
+	for key := range m.store.secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	resp := &secrets_pb.ListSecretsResp{}
+	for _, key := range keys {
+		versions := m.store.secrets[key]
+		resp.Secrets = append(resp.Secrets, &common_config_pb.Secret{
+			Key:     key,
+			Version: versions[len(versions)-1].version,
+		})
+	}
+	return resp, nil
+}
+
+func (m *secretsManager) ListSecretVersions(ctx context.Context, req *secrets_pb.ListSecretVersionsReq) (*secrets_pb.ListSecretVersionsResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	versions, ok := m.store.secrets[req.Key]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "secret %s not found", req.Key)
+	}
+	resp := &secrets_pb.ListSecretVersionsResp{}
+	for _, version := range versions {
+		resp.Versions = append(resp.Versions, version.version)
+	}
+	return resp, nil
+}
+
+func (m *secretsManager) SetSecret(ctx context.Context, req *secrets_pb.SetSecretReq) (*secrets_pb.SetSecretResp, error) {
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "secret key is required")
+	}
+	if req.Value == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "secret %s value is required", req.Key)
+	}
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	version := m.store.newVersion()
+	m.store.secrets[req.Key] = append(m.store.secrets[req.Key], secretVersion{
+		version: version,
+		value:   req.Value,
+	})
+	return &secrets_pb.SetSecretResp{
+		Version: version,
+	}, nil
+}
+
+func (m *secretsManager) DeleteSecret(ctx context.Context, req *secrets_pb.DeleteSecretReq) (*secrets_pb.DeleteSecretResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if _, ok := m.store.secrets[req.Key]; !ok {
+		return nil, status.Errorf(codes.NotFound, "secret %s not found", req.Key)
+	}
+	delete(m.store.secrets, req.Key)
+	return &secrets_pb.DeleteSecretResp{}, nil
+}
+
+func (m *secretsManager) DeleteSecretVersion(ctx context.Context, req *secrets_pb.DeleteSecretVersionReq) (*secrets_pb.DeleteSecretVersionResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if err := m.store.deleteSecretVersion(req.Key, req.Version); err != nil {
+		return nil, err
+	}
+	return &secrets_pb.DeleteSecretVersionResp{}, nil
+}
+
+// deleteSecretVersion must be called with mu held. Deleting the last version
+// deletes the secret.
+func (s *store) deleteSecretVersion(key, version string) error {
+	versions, ok := s.secrets[key]
+	if !ok {
+		return status.Errorf(codes.NotFound, "secret %s not found", key)
+	}
+	for i, v := range versions {
+		if v.version != version {
+			continue
+		}
+		versions = append(versions[:i], versions[i+1:]...)
+		if len(versions) == 0 {
+			delete(s.secrets, key)
+		} else {
+			s.secrets[key] = versions
+		}
+		return nil
+	}
+	return status.Errorf(codes.NotFound, "version %s of secret %s not found", version, key)
+}
+
+// SecretValue returns the value written to a version of a secret, so tests
+// can check what the provider sent without it ever appearing in state.
+func (s *Server) SecretValue(key, version string) (string, bool) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	for _, v := range s.store.secrets[key] {
+		if v.version == version {
+			return v.value, true
+		}
+	}
+	return "", false
+}
+
+// DeleteSecretVersion deletes a version of a secret behind the provider's
+// back, to simulate changes made outside of Terraform.
+func (s *Server) DeleteSecretVersion(key, version string) error {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	return s.store.deleteSecretVersion(key, version)
+}
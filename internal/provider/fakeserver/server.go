@@ -0,0 +1,279 @@
+// Package fakeserver implements an in-memory Prodvana API server that the
+// provider's acceptance tests can run against without a real organization.
+//
+// Only the RPCs the provider uses are implemented; everything else returns
+// codes.Unimplemented via the embedded Unimplemented*Server types.
+package fakeserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
+	ds_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state"
+	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
+	labels_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/labels"
+	obj_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	secrets_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/secrets"
+	svc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/service"
+	workflow_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/workflow"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// OrgSlug is the organization the fake server pretends to serve.
+	OrgSlug = "fake"
+	// APIToken is the only bearer token the fake server accepts.
+	APIToken = "fake-api-token"
+	// DefaultRuntime is the name of the Kubernetes runtime every fake server starts with.
+	DefaultRuntime = "default"
+
+	serverName = "fakeserver.prodvana.test"
+	bufSize    = 1024 * 1024
+)
+
+// Server is an in-process Prodvana API server listening on an in-memory
+// connection. Use DialOptions to point a gRPC client (or the provider) at it.
+type Server struct {
+	store    *store
+	grpc     *grpc.Server
+	listener *bufconn.Listener
+	certPool *x509.CertPool
+}
+
+// New starts a fake server seeded with a connected Kubernetes runtime named
+// DefaultRuntime.
+func New() (*Server, error) {
+	cert, pool, err := selfSignedCertificate()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate TLS certificate")
+	}
+
+	s := &Server{
+		store:    newStore(),
+		listener: bufconn.Listen(bufSize),
+		certPool: pool,
+	}
+	s.grpc = grpc.NewServer(
+		grpc.Creds(credentials.NewServerTLSFromCert(cert)),
+		grpc.UnaryInterceptor(authInterceptor),
+	)
+	env_pb.RegisterEnvironmentManagerServer(s.grpc, &environmentManager{store: s.store})
+	app_pb.RegisterApplicationManagerServer(s.grpc, &applicationManager{store: s.store})
+	rc_pb.RegisterReleaseChannelManagerServer(s.grpc, &releaseChannelManager{store: s.store})
+	svc_pb.RegisterServiceManagerServer(s.grpc, &serviceManager{store: s.store})
+	ds_pb.RegisterDesiredStateManagerServer(s.grpc, &desiredStateManager{store: s.store})
+	prot_pb.RegisterProtectionManagerServer(s.grpc, &protectionManager{store: s.store})
+	workflow_pb.RegisterWorkflowManagerServer(s.grpc, &workflowManager{store: s.store})
+	secrets_pb.RegisterSecretsManagerServer(s.grpc, &secretsManager{store: s.store})
+	obj_pb.RegisterObjectManagerServer(s.grpc, &objectManager{store: s.store})
+
+	s.store.clusters[DefaultRuntime] = &cluster{
+		info: &env_pb.ListClustersResp_ClusterInfo{
+			Name: DefaultRuntime,
+			Id:   s.store.newId(),
+			Type: env_pb.ClusterType_K8S,
+			Config: &env_pb.ClusterConfig{
+				Name: DefaultRuntime,
+			},
+			Auth: &env_pb.ClusterAuth{
+				AuthOneof: &env_pb.ClusterAuth_K8S{
+					K8S: &env_pb.ClusterAuth_K8SAuth{
+						AgentExternallyManaged: true,
+					},
+				},
+			},
+		},
+		agentApiToken:  s.store.newToken(),
+		agentConnected: true,
+	}
+
+	go func() {
+		_ = s.grpc.Serve(s.listener)
+	}()
+
+	return s, nil
+}
+
+// Close stops the server and drops all state.
+func (s *Server) Close() {
+	s.grpc.Stop()
+}
+
+// DialOptions returns the options needed to reach this server. The address
+// passed to grpc.Dial is ignored, and the options override any transport
+// credentials set before them.
+func (s *Server) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			RootCAs:    s.certPool,
+			ServerName: serverName,
+		})),
+	}
+}
+
+// Dial opens an authenticated client connection to the server, e.g. to seed
+// objects before a test runs.
+func (s *Server) Dial(ctx context.Context) (*grpc.ClientConn, error) {
+	options := append(s.DialOptions(), grpc.WithPerRPCCredentials(bearerToken(APIToken)))
+	return grpc.DialContext(ctx, serverName, options...)
+}
+
+// SetAgentConnected controls whether the agent for the named runtime reports
+// heartbeats. Newly linked runtimes start out disconnected.
+func (s *Server) SetAgentConnected(runtime string, connected bool) error {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	c, ok := s.store.clusters[runtime]
+	if !ok {
+		return status.Errorf(codes.NotFound, "runtime %s not found", runtime)
+	}
+	c.agentConnected = connected
+	return nil
+}
+
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, in ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + string(t),
+	}, nil
+}
+
+func (bearerToken) RequireTransportSecurity() bool {
+	return true
+}
+
+func authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := md.Get("authorization")
+	if len(auth) != 1 || auth[0] != "Bearer "+APIToken {
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing API token")
+	}
+	return handler(ctx, req)
+}
+
+func selfSignedCertificate() (*tls.Certificate, *x509.CertPool, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: serverName},
+		DNSNames:              []string{serverName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(parsed)
+	return &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        parsed,
+	}, pool, nil
+}
+
+// store holds every object the fake server knows about. All service
+// implementations share a single store and lock.
+type store struct {
+	mu sync.Mutex
+
+	clusters        map[string]*cluster
+	applications    map[string]*app_pb.Application
+	releaseChannels map[string]map[string]*rc_pb.ReleaseChannel
+	services        map[string]map[string]*svc_pb.Service
+	// serviceVersions maps a service id to every config version created for it.
+	serviceVersions map[string]map[string]*svc_pb.ServiceConfig
+	desiredStates   map[string]*desiredState
+	// convergenceFailures maps <application>/<service> to the message its
+	// desired states fail with, see Server.SetConvergenceFailure.
+	convergenceFailures map[string]string
+	protections         map[string]*prot_pb.Protection
+	registries          map[string]*workflow_pb.ContainerRegistryIntegration
+	secrets             map[string][]secretVersion
+	// applicationLabels maps an application name to its labels, see
+	// Server.SetApplicationLabels.
+	applicationLabels map[string][]*labels_pb.LabelDefinition
+
+	nextVersion int
+}
+
+type cluster struct {
+	info           *env_pb.ListClustersResp_ClusterInfo
+	agentApiToken  string
+	agentConnected bool
+}
+
+func newStore() *store {
+	return &store{
+		clusters:            map[string]*cluster{},
+		applications:        map[string]*app_pb.Application{},
+		releaseChannels:     map[string]map[string]*rc_pb.ReleaseChannel{},
+		services:            map[string]map[string]*svc_pb.Service{},
+		serviceVersions:     map[string]map[string]*svc_pb.ServiceConfig{},
+		desiredStates:       map[string]*desiredState{},
+		convergenceFailures: map[string]string{},
+		protections:         map[string]*prot_pb.Protection{},
+		registries:          map[string]*workflow_pb.ContainerRegistryIntegration{},
+		secrets:             map[string][]secretVersion{},
+		applicationLabels:   map[string][]*labels_pb.LabelDefinition{},
+	}
+}
+
+func (s *store) newId() string {
+	return randomHex(16)
+}
+
+func (s *store) newToken() string {
+	return "pvn-agent-" + randomHex(24)
+}
+
+// newVersion returns a monotonically increasing version string, must be
+// called with mu held.
+func (s *store) newVersion() string {
+	s.nextVersion++
+	return strconv.Itoa(s.nextVersion)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,193 @@
+package fakeserver
+
+import (
+	"context"
+	"sort"
+
+	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/object"
+	svc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type serviceManager struct {
+	svc_pb.UnimplementedServiceManagerServer
+	store *store
+}
+
+func (m *serviceManager) ConfigureService(ctx context.Context, req *svc_pb.ConfigureServiceReq) (*svc_pb.ConfigureServiceResp, error) {
+	config := req.ServiceConfig
+	if config == nil || config.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "service name is required")
+	}
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	for _, perRc := range config.PerReleaseChannel {
+		if _, ok := m.store.releaseChannels[app.Meta.Name][perRc.ReleaseChannel]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "service %s references unknown release channel %s", config.Name, perRc.ReleaseChannel)
+		}
+	}
+	config = proto.Clone(config).(*svc_pb.ServiceConfig)
+	config.Application = app.Meta.Name
+
+	services := m.store.services[app.Meta.Name]
+	svc, ok := services[config.Name]
+	changed := !ok || req.ForceCreateNewVersion || !proto.Equal(svc.Config, config)
+	if !ok {
+		svc = &svc_pb.Service{
+			Meta: &object.ObjectMeta{
+				Id:   m.store.newId(),
+				Name: config.Name,
+			},
+		}
+		services[config.Name] = svc
+	}
+	svc.Config = config
+	svc.Meta.Source = req.Source
+	svc.Meta.SourceMetadata = req.SourceMetadata
+	if changed {
+		svc.Meta.ConfigVersion = m.store.newVersion()
+		m.store.recordServiceVersion(svc.Meta.Id, svc.Meta.ConfigVersion, config)
+	}
+	return &svc_pb.ConfigureServiceResp{
+		ServiceId:         svc.Meta.Id,
+		ConfigVersion:     svc.Meta.ConfigVersion,
+		CreatedNewVersion: changed,
+	}, nil
+}
+
+func (m *serviceManager) GetService(ctx context.Context, req *svc_pb.GetServiceReq) (*svc_pb.GetServiceResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	svc, err := m.store.service(req.Application, req.Service)
+	if err != nil {
+		return nil, err
+	}
+	return &svc_pb.GetServiceResp{
+		Service: proto.Clone(svc).(*svc_pb.Service),
+	}, nil
+}
+
+func (m *serviceManager) GetServiceConfig(ctx context.Context, req *svc_pb.GetServiceConfigReq) (*svc_pb.GetServiceConfigResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	svc, err := m.store.service(req.Application, req.Service)
+	if err != nil {
+		return nil, err
+	}
+	version := svc.Meta.ConfigVersion
+	if req.ConfigVersion != "" {
+		version = req.ConfigVersion
+	}
+	config, ok := m.store.serviceVersions[svc.Meta.Id][version]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "version %s of service %s not found", version, req.Service)
+	}
+	return &svc_pb.GetServiceConfigResp{
+		Config:         proto.Clone(config).(*svc_pb.ServiceConfig),
+		InputConfig:    proto.Clone(config).(*svc_pb.ServiceConfig),
+		CompiledConfig: proto.Clone(config).(*svc_pb.ServiceConfig),
+		ConfigVersion:  version,
+	}, nil
+}
+
+// ApplyParameters materializes a new service version from either an inline
+// config or an existing version. Parameters are accepted but not templated
+// into the config. The service's current config version is left untouched.
+func (m *serviceManager) ApplyParameters(ctx context.Context, req *svc_pb.ApplyParametersReq) (*svc_pb.ApplyParametersResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	var config *svc_pb.ServiceConfig
+	switch oneof := req.Oneof.(type) {
+	case *svc_pb.ApplyParametersReq_ServiceConfig:
+		config = oneof.ServiceConfig
+	case *svc_pb.ApplyParametersReq_ServiceConfigVersion:
+		ref := oneof.ServiceConfigVersion
+		svc, err := m.store.service(ref.Application, ref.Service)
+		if err != nil {
+			return nil, err
+		}
+		existing, ok := m.store.serviceVersions[svc.Meta.Id][ref.ServiceConfigVersion]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "version %s of service %s not found", ref.ServiceConfigVersion, ref.Service)
+		}
+		config = existing
+	default:
+		return nil, status.Error(codes.InvalidArgument, "service config or service config version is required")
+	}
+	svc, err := m.store.service(req.Application, config.Name)
+	if err != nil {
+		return nil, err
+	}
+	version := m.store.newVersion()
+	m.store.recordServiceVersion(svc.Meta.Id, version, config)
+	return &svc_pb.ApplyParametersResp{
+		ServiceId: svc.Meta.Id,
+		Version:   version,
+	}, nil
+}
+
+func (m *serviceManager) ListServices(ctx context.Context, req *svc_pb.ListServicesReq) (*svc_pb.ListServicesResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	app, err := m.store.application(req.Application)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(m.store.services[app.Meta.Name]))
+	for name := range m.store.services[app.Meta.Name] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resp := &svc_pb.ListServicesResp{}
+	for _, name := range names {
+		resp.Services = append(resp.Services, proto.Clone(m.store.services[app.Meta.Name][name]).(*svc_pb.Service))
+	}
+	return resp, nil
+}
+
+func (m *serviceManager) DeleteService(ctx context.Context, req *svc_pb.DeleteServiceReq) (*svc_pb.DeleteServiceResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	svc, err := m.store.service(req.Application, req.Service)
+	if err != nil {
+		return nil, err
+	}
+	delete(m.store.services[svc.Config.Application], svc.Meta.Name)
+	delete(m.store.serviceVersions, svc.Meta.Id)
+	return &svc_pb.DeleteServiceResp{}, nil
+}
+
+// service finds a service by application and name, must be called with mu
+// held.
+func (s *store) service(application, name string) (*svc_pb.Service, error) {
+	app, err := s.application(application)
+	if err != nil {
+		return nil, err
+	}
+	svc, ok := s.services[app.Meta.Name][name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "service %s not found in application %s", name, application)
+	}
+	return svc, nil
+}
+
+// recordServiceVersion stores a copy of config under version, must be called
+// with mu held.
+func (s *store) recordServiceVersion(serviceId, version string, config *svc_pb.ServiceConfig) {
+	if s.serviceVersions[serviceId] == nil {
+		s.serviceVersions[serviceId] = map[string]*svc_pb.ServiceConfig{}
+	}
+	s.serviceVersions[serviceId][version] = proto.Clone(config).(*svc_pb.ServiceConfig)
+}
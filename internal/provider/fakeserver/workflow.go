@@ -0,0 +1,104 @@
+package fakeserver
+
+import (
+	"context"
+	"sort"
+
+	workflow_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/workflow"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type workflowManager struct {
+	workflow_pb.UnimplementedWorkflowManagerServer
+	store *store
+}
+
+// CreateContainerRegistryIntegration upserts a registry by name, keeping the
+// integration id stable across updates.
+func (m *workflowManager) CreateContainerRegistryIntegration(ctx context.Context, req *workflow_pb.CreateContainerRegistryIntegrationReq) (*workflow_pb.CreateContainerRegistryIntegrationRes, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	registry := &workflow_pb.ContainerRegistryIntegration{
+		IntegrationId: m.store.newId(),
+		Name:          req.Name,
+		Url:           req.Url,
+		Type:          req.Type.String(),
+		Status:        workflow_pb.RegistryStatus_CONNECTED,
+	}
+	if existing, ok := m.store.registries[req.Name]; ok {
+		registry.IntegrationId = existing.IntegrationId
+	}
+	if ecr := req.GetEcrOptions(); ecr != nil {
+		registry.RegistryInfo = &workflow_pb.ContainerRegistryIntegration_EcrInfo{
+			EcrInfo: &workflow_pb.ContainerRegistryIntegration_ECRInfo{
+				Region: ecr.Region,
+			},
+		}
+	}
+	m.store.registries[req.Name] = registry
+
+	return &workflow_pb.CreateContainerRegistryIntegrationRes{
+		IntegrationId: registry.IntegrationId,
+	}, nil
+}
+
+func (m *workflowManager) GetContainerRegistryIntegration(ctx context.Context, req *workflow_pb.GetContainerRegistryIntegrationReq) (*workflow_pb.GetContainerRegistryIntegrationResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	registry, ok := m.store.registries[req.RegistryName]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "container registry %s not found", req.RegistryName)
+	}
+	return &workflow_pb.GetContainerRegistryIntegrationResp{
+		Registry: proto.Clone(registry).(*workflow_pb.ContainerRegistryIntegration),
+	}, nil
+}
+
+func (m *workflowManager) ListContainerRegistryIntegrations(ctx context.Context, req *workflow_pb.ListContainerRegistryIntegrationsReq) (*workflow_pb.ListContainerRegistryIntegrationsResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	names := make([]string, 0, len(m.store.registries))
+	for name := range m.store.registries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	resp := &workflow_pb.ListContainerRegistryIntegrationsResp{}
+	for _, name := range names {
+		registry := m.store.registries[name]
+		info := &workflow_pb.ListContainerRegistryIntegrationsResp_ContainerRegistryIntegrationInfo{
+			IntegrationId: registry.IntegrationId,
+			Name:          registry.Name,
+			Url:           registry.Url,
+			Type:          registry.Type,
+			Status:        registry.Status,
+		}
+		if ecr := registry.GetEcrInfo(); ecr != nil {
+			info.RegistryInfo = &workflow_pb.ListContainerRegistryIntegrationsResp_ContainerRegistryIntegrationInfo_EcrInfo{
+				EcrInfo: &workflow_pb.ListContainerRegistryIntegrationsResp_ECRInfo{
+					Region: ecr.Region,
+				},
+			}
+		}
+		resp.ContainerRegistries = append(resp.ContainerRegistries, info)
+	}
+	return resp, nil
+}
+
+func (m *workflowManager) DeleteContainerRegistryIntegration(ctx context.Context, req *workflow_pb.DeleteContainerRegistryIntegrationReq) (*workflow_pb.DeleteContainerRegistryIntegrationResp, error) {
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	if _, ok := m.store.registries[req.RegistryName]; !ok {
+		return nil, status.Errorf(codes.NotFound, "container registry %s not found", req.RegistryName)
+	}
+	delete(m.store.registries, req.RegistryName)
+	return &workflow_pb.DeleteContainerRegistryIntegrationResp{}, nil
+}
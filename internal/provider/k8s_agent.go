@@ -0,0 +1,449 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/maps"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// agentModel is the optional `agent` block of prodvana_managed_k8s_runtime,
+// it customizes the Kubernetes objects the agent is installed with.
+type agentModel struct {
+	Namespace          types.String         `tfsdk:"namespace"`
+	Resources          *agentResourcesModel `tfsdk:"resources"`
+	NodeSelector       types.Map            `tfsdk:"node_selector"`
+	Tolerations        []*agentToleration   `tfsdk:"tolerations"`
+	Affinity           types.String         `tfsdk:"affinity"`
+	PriorityClassName  types.String         `tfsdk:"priority_class_name"`
+	PodSecurityContext types.String         `tfsdk:"pod_security_context"`
+	SecurityContext    types.String         `tfsdk:"security_context"`
+	Labels             types.Map            `tfsdk:"labels"`
+	Annotations        types.Map            `tfsdk:"annotations"`
+	ImagePullSecrets   types.List           `tfsdk:"image_pull_secrets"`
+	Rbac               *agentRbacModel      `tfsdk:"rbac"`
+}
+
+type agentResourcesModel struct {
+	Requests types.Map `tfsdk:"requests"`
+	Limits   types.Map `tfsdk:"limits"`
+}
+
+type agentToleration struct {
+	Key               types.String `tfsdk:"key"`
+	Operator          types.String `tfsdk:"operator"`
+	Value             types.String `tfsdk:"value"`
+	Effect            types.String `tfsdk:"effect"`
+	TolerationSeconds types.Int64  `tfsdk:"toleration_seconds"`
+}
+
+func agentSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Customizes the Kubernetes objects the agent is installed with, e.g. to satisfy Pod Security admission or to schedule the agent on tainted nodes",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Namespace to install the agent in. Defaults to `%s`", defaultAgentNamespace),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 63),
+				},
+			},
+			"resources": schema.SingleNestedAttribute{
+				MarkdownDescription: "Compute resources of the agent container",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"requests": schema.MapAttribute{
+						MarkdownDescription: "Resource requests, e.g. `{ cpu = \"100m\", memory = \"128Mi\" }`",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"limits": schema.MapAttribute{
+						MarkdownDescription: "Resource limits, e.g. `{ memory = \"512Mi\" }`",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+			"node_selector": schema.MapAttribute{
+				MarkdownDescription: "Node labels the agent pod must be scheduled on",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"tolerations": schema.ListNestedAttribute{
+				MarkdownDescription: "Tolerations of the agent pod",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "Taint key the toleration applies to, empty matches all keys",
+							Optional:            true,
+						},
+						"operator": schema.StringAttribute{
+							MarkdownDescription: "`Equal` or `Exists`. Defaults to `Equal`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists)),
+							},
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Taint value the toleration matches",
+							Optional:            true,
+						},
+						"effect": schema.StringAttribute{
+							MarkdownDescription: "Taint effect to match, `NoSchedule`, `PreferNoSchedule` or `NoExecute`, empty matches all effects",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)),
+							},
+						},
+						"toleration_seconds": schema.Int64Attribute{
+							MarkdownDescription: "How long a `NoExecute` toleration tolerates the taint",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"affinity": schema.StringAttribute{
+				MarkdownDescription: "Affinity of the agent pod, as a JSON or YAML encoded Kubernetes `Affinity`, e.g. `jsonencode({ nodeAffinity = { ... } })`",
+				Optional:            true,
+			},
+			"priority_class_name": schema.StringAttribute{
+				MarkdownDescription: "Priority class of the agent pod",
+				Optional:            true,
+			},
+			"pod_security_context": schema.StringAttribute{
+				MarkdownDescription: "Security context of the agent pod, as a JSON or YAML encoded Kubernetes `PodSecurityContext`",
+				Optional:            true,
+			},
+			"security_context": schema.StringAttribute{
+				MarkdownDescription: "Security context of the agent container, as a JSON or YAML encoded Kubernetes `SecurityContext`",
+				Optional:            true,
+			},
+			"labels": schema.MapAttribute{
+				MarkdownDescription: "Extra labels for the agent deployment and pod",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"annotations": schema.MapAttribute{
+				MarkdownDescription: "Extra annotations for the agent deployment and pod",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"image_pull_secrets": schema.ListAttribute{
+				MarkdownDescription: "Names of secrets in the agent namespace to pull the agent image with",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"rbac": agentRbacSchema(),
+		},
+	}
+}
+
+// agentOptions holds everything needed to render the agent's Kubernetes objects.
+type agentOptions struct {
+	namespace string
+	runtimeId string
+	image     string
+	args      []string
+	env       map[string]string
+
+	resources          corev1.ResourceRequirements
+	nodeSelector       map[string]string
+	tolerations        []corev1.Toleration
+	affinity           *corev1.Affinity
+	priorityClassName  string
+	podSecurityContext *corev1.PodSecurityContext
+	securityContext    *corev1.SecurityContext
+	labels             map[string]string
+	annotations        map[string]string
+	imagePullSecrets   []string
+
+	rbacMode       string
+	rbacNamespaces []string
+	clusterRole    string
+}
+
+func agentNamespace(agent *agentModel) string {
+	if agent == nil || agent.Namespace.IsNull() || agent.Namespace.IsUnknown() {
+		return defaultAgentNamespace
+	}
+	return agent.Namespace.ValueString()
+}
+
+func stringMapFromTerraform(ctx context.Context, m types.Map, diags *diag.Diagnostics) map[string]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+	values := map[string]string{}
+	diags.Append(m.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+func resourceListFromTerraform(ctx context.Context, attrPath path.Path, m types.Map, diags *diag.Diagnostics) corev1.ResourceList {
+	values := stringMapFromTerraform(ctx, m, diags)
+	if len(values) == 0 {
+		return nil
+	}
+	list := corev1.ResourceList{}
+	for name, value := range values {
+		quantity, err := k8s_resource.ParseQuantity(value)
+		if err != nil {
+			diags.AddAttributeError(attrPath.AtMapKey(name), "Invalid Resource Quantity", fmt.Sprintf("%q is not a valid quantity: %s", value, err))
+			continue
+		}
+		list[corev1.ResourceName(name)] = quantity
+	}
+	return list
+}
+
+// decodeKubernetesObject strictly decodes a JSON or YAML encoded Kubernetes
+// type, so typos in field names are reported instead of silently dropped.
+func decodeKubernetesObject(attrPath path.Path, value types.String, into interface{}, diags *diag.Diagnostics) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return false
+	}
+	if err := yaml.UnmarshalStrict([]byte(value.ValueString()), into); err != nil {
+		diags.AddAttributeError(attrPath, "Invalid Kubernetes Object", fmt.Sprintf("Unable to decode %s: %s", attrPath, err))
+		return false
+	}
+	return true
+}
+
+// agentOptionsFromModel converts the agent block into agentOptions, the
+// runtime specific fields (runtime id, image, args, env) are left to the caller.
+func agentOptionsFromModel(ctx context.Context, agent *agentModel) (*agentOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := &agentOptions{
+		namespace: agentNamespace(agent),
+		rbacMode:  agentRbacModeClusterAdmin,
+	}
+	if agent == nil {
+		return opts, diags
+	}
+	agentPath := path.Root("agent")
+
+	if agent.Resources != nil {
+		opts.resources.Requests = resourceListFromTerraform(ctx, agentPath.AtName("resources").AtName("requests"), agent.Resources.Requests, &diags)
+		opts.resources.Limits = resourceListFromTerraform(ctx, agentPath.AtName("resources").AtName("limits"), agent.Resources.Limits, &diags)
+	}
+	opts.nodeSelector = stringMapFromTerraform(ctx, agent.NodeSelector, &diags)
+	for _, toleration := range agent.Tolerations {
+		t := corev1.Toleration{
+			Key:      toleration.Key.ValueString(),
+			Operator: corev1.TolerationOperator(toleration.Operator.ValueString()),
+			Value:    toleration.Value.ValueString(),
+			Effect:   corev1.TaintEffect(toleration.Effect.ValueString()),
+		}
+		if !toleration.TolerationSeconds.IsNull() && !toleration.TolerationSeconds.IsUnknown() {
+			seconds := toleration.TolerationSeconds.ValueInt64()
+			t.TolerationSeconds = &seconds
+		}
+		opts.tolerations = append(opts.tolerations, t)
+	}
+
+	affinity := &corev1.Affinity{}
+	if decodeKubernetesObject(agentPath.AtName("affinity"), agent.Affinity, affinity, &diags) {
+		opts.affinity = affinity
+	}
+	podSecurityContext := &corev1.PodSecurityContext{}
+	if decodeKubernetesObject(agentPath.AtName("pod_security_context"), agent.PodSecurityContext, podSecurityContext, &diags) {
+		opts.podSecurityContext = podSecurityContext
+	}
+	securityContext := &corev1.SecurityContext{}
+	if decodeKubernetesObject(agentPath.AtName("security_context"), agent.SecurityContext, securityContext, &diags) {
+		opts.securityContext = securityContext
+	}
+
+	opts.priorityClassName = agent.PriorityClassName.ValueString()
+	opts.labels = stringMapFromTerraform(ctx, agent.Labels, &diags)
+	opts.annotations = stringMapFromTerraform(ctx, agent.Annotations, &diags)
+	if !agent.ImagePullSecrets.IsNull() && !agent.ImagePullSecrets.IsUnknown() {
+		diags.Append(agent.ImagePullSecrets.ElementsAs(ctx, &opts.imagePullSecrets, false)...)
+	}
+	agentRbacOptionsFromModel(ctx, agent.Rbac, opts, &diags)
+	return opts, diags
+}
+
+// agentObjects are the Kubernetes objects the agent is installed with.
+// Which of the RBAC objects are set depends on the rbac mode.
+type agentObjects struct {
+	namespace          *corev1.Namespace
+	serviceAccount     *corev1.ServiceAccount
+	clusterRole        *rbacv1.ClusterRole
+	clusterRoleBinding *rbacv1.ClusterRoleBinding
+	roles              []*rbacv1.Role
+	roleBindings       []*rbacv1.RoleBinding
+	deployment         *appsv1.Deployment
+}
+
+func mergeStringMaps(base, extra map[string]string) map[string]string {
+	merged := map[string]string{}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	// base wins, the agent relies on its own labels and annotations
+	for k, v := range base {
+		merged[k] = v
+	}
+	return merged
+}
+
+func buildAgentObjects(opts *agentOptions) *agentObjects {
+	namespace := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: opts.namespace,
+		},
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceAccountName,
+			Namespace: opts.namespace,
+		},
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "PVN_NAMESPACE",
+			Value: opts.namespace,
+		},
+		{
+			Name:  "PVN_RELEASE_CHANNEL",
+			Value: "prodvana",
+		},
+	}
+	// sorted so the rendered deployment does not change between runs
+	envNames := maps.Keys(opts.env)
+	sort.Strings(envNames)
+	for _, k := range envNames {
+		env = append(env, corev1.EnvVar{
+			Name:  k,
+			Value: opts.env[k],
+		})
+	}
+
+	var imagePullSecrets []corev1.LocalObjectReference
+	for _, name := range opts.imagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+
+	podLabels := mergeStringMaps(map[string]string{
+		"app":                 agentDeploymentName,
+		"prodvana.io/service": agentDeploymentName,
+	}, opts.labels)
+
+	var replicas int32 = 1
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentDeploymentName,
+			Namespace: opts.namespace,
+			Labels:    opts.labels,
+			Annotations: mergeStringMaps(map[string]string{
+				agentRuntimeIdAnnotation: opts.runtimeId,
+			}, opts.annotations),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": agentDeploymentName,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: opts.annotations,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: serviceAccount.Name,
+					NodeSelector:       opts.nodeSelector,
+					Tolerations:        opts.tolerations,
+					Affinity:           opts.affinity,
+					PriorityClassName:  opts.priorityClassName,
+					SecurityContext:    opts.podSecurityContext,
+					ImagePullSecrets:   imagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            "default",
+							Args:            opts.args,
+							Env:             env,
+							Image:           opts.image,
+							ImagePullPolicy: corev1.PullAlways,
+							Resources:       opts.resources,
+							SecurityContext: opts.securityContext,
+							Ports: []corev1.ContainerPort{
+								{
+									ContainerPort: 5100,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objects := &agentObjects{
+		namespace:      namespace,
+		serviceAccount: serviceAccount,
+		deployment:     deployment,
+	}
+	buildAgentRbacObjects(opts, objects)
+	return objects
+}
+
+// staleAgentObjects returns the objects of current that are not part of
+// desired. Server-side apply never removes objects, these must be deleted.
+func staleAgentObjects(current, desired *agentObjects) *agentObjects {
+	stale := &agentObjects{}
+	if current.namespace.Name != desired.namespace.Name {
+		stale.namespace = current.namespace
+		stale.serviceAccount = current.serviceAccount
+		stale.deployment = current.deployment
+	}
+	if current.clusterRole != nil && desired.clusterRole == nil {
+		stale.clusterRole = current.clusterRole
+	}
+	// the role of a binding is immutable, binding a different role means recreating it
+	if current.clusterRoleBinding != nil && (desired.clusterRoleBinding == nil || current.clusterRoleBinding.RoleRef != desired.clusterRoleBinding.RoleRef) {
+		stale.clusterRoleBinding = current.clusterRoleBinding
+	}
+	desiredRoleNamespaces := map[string]bool{}
+	for _, role := range desired.roles {
+		desiredRoleNamespaces[role.Namespace] = true
+	}
+	for i, role := range current.roles {
+		if !desiredRoleNamespaces[role.Namespace] {
+			stale.roles = append(stale.roles, role)
+			stale.roleBindings = append(stale.roleBindings, current.roleBindings[i])
+		}
+	}
+	return stale
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// agentObjectKey identifies an agent object in agent_object_hashes and agent_drift.
+func agentObjectKey(kind, namespace, name string) string {
+	if namespace == "" {
+		return fmt.Sprintf("%s/%s", kind, name)
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+func hashKubernetesObject(obj interface{}) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// pickStringMap returns the entries of values whose keys are in keys, so
+// labels and annotations added by others are not reported as drift.
+func pickStringMap(values, keys map[string]string) map[string]string {
+	picked := map[string]string{}
+	for k := range keys {
+		if v, ok := values[k]; ok {
+			picked[k] = v
+		}
+	}
+	return picked
+}
+
+// projectDeployment keeps the fields of a live deployment that the provider
+// sets, status and fields owned by controllers are dropped.
+func projectDeployment(live, expected *appsv1.Deployment) *appsv1.Deployment {
+	template := live.Spec.Template
+	projected := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      pickStringMap(live.Labels, expected.Labels),
+			Annotations: pickStringMap(live.Annotations, expected.Annotations),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: live.Spec.Replicas,
+			Selector: live.Spec.Selector,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      pickStringMap(template.Labels, expected.Spec.Template.Labels),
+					Annotations: pickStringMap(template.Annotations, expected.Spec.Template.Annotations),
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: template.Spec.ServiceAccountName,
+					NodeSelector:       template.Spec.NodeSelector,
+					Tolerations:        template.Spec.Tolerations,
+					Affinity:           template.Spec.Affinity,
+					PriorityClassName:  template.Spec.PriorityClassName,
+					SecurityContext:    template.Spec.SecurityContext,
+					ImagePullSecrets:   template.Spec.ImagePullSecrets,
+				},
+			},
+		},
+	}
+	for _, container := range template.Spec.Containers {
+		projected.Spec.Template.Spec.Containers = append(projected.Spec.Template.Spec.Containers, corev1.Container{
+			Name:            container.Name,
+			Args:            container.Args,
+			Env:             container.Env,
+			Image:           container.Image,
+			ImagePullPolicy: container.ImagePullPolicy,
+			Resources:       container.Resources,
+			SecurityContext: container.SecurityContext,
+			Ports:           container.Ports,
+		})
+	}
+	return projected
+}
+
+// agentObjectHashes hashes the live counterpart of every object in expected,
+// objects missing from the cluster are left out.
+func agentObjectHashes(ctx context.Context, clientSet kubernetes.Interface, expected *agentObjects) (map[string]string, error) {
+	hashes := map[string]string{}
+	add := func(kind, namespace, name string, get func() (interface{}, error)) error {
+		key := agentObjectKey(kind, namespace, name)
+		projected, err := get()
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Unable to read %s", key)
+		}
+		hash, err := hashKubernetesObject(projected)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to hash %s", key)
+		}
+		hashes[key] = hash
+		return nil
+	}
+
+	err := add("Namespace", "", expected.namespace.Name, func() (interface{}, error) {
+		live, err := clientSet.CoreV1().Namespaces().Get(ctx, expected.namespace.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return live.Name, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount := expected.serviceAccount
+	err = add("ServiceAccount", serviceAccount.Namespace, serviceAccount.Name, func() (interface{}, error) {
+		live, err := clientSet.CoreV1().ServiceAccounts(serviceAccount.Namespace).Get(ctx, serviceAccount.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return live.Name, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if expected.clusterRole != nil {
+		err = add("ClusterRole", "", expected.clusterRole.Name, func() (interface{}, error) {
+			live, err := clientSet.RbacV1().ClusterRoles().Get(ctx, expected.clusterRole.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return live.Rules, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if expected.clusterRoleBinding != nil {
+		err = add("ClusterRoleBinding", "", expected.clusterRoleBinding.Name, func() (interface{}, error) {
+			live, err := clientSet.RbacV1().ClusterRoleBindings().Get(ctx, expected.clusterRoleBinding.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &rbacv1.ClusterRoleBinding{Subjects: live.Subjects, RoleRef: live.RoleRef}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, role := range expected.roles {
+		role := role
+		err = add("Role", role.Namespace, role.Name, func() (interface{}, error) {
+			live, err := clientSet.RbacV1().Roles(role.Namespace).Get(ctx, role.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return live.Rules, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, roleBinding := range expected.roleBindings {
+		roleBinding := roleBinding
+		err = add("RoleBinding", roleBinding.Namespace, roleBinding.Name, func() (interface{}, error) {
+			live, err := clientSet.RbacV1().RoleBindings(roleBinding.Namespace).Get(ctx, roleBinding.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return &rbacv1.RoleBinding{Subjects: live.Subjects, RoleRef: live.RoleRef}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deployment := expected.deployment
+	err = add("Deployment", deployment.Namespace, deployment.Name, func() (interface{}, error) {
+		live, err := clientSet.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return projectDeployment(live, deployment), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+// agentDrift returns the sorted keys of objects that were changed or deleted since they were applied.
+func agentDrift(applied, live map[string]string) []string {
+	drift := []string{}
+	for key, hash := range applied {
+		if live[key] != hash {
+			drift = append(drift, key)
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pkg/errors"
+	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &K8sAgentManifestDataSource{}
+
+func NewK8sAgentManifestDataSource() datasource.DataSource {
+	return &K8sAgentManifestDataSource{}
+}
+
+// K8sAgentManifestDataSource renders the agent objects of a Kubernetes
+// runtime for installs outside the provider, e.g. with kubectl or Argo CD.
+type K8sAgentManifestDataSource struct {
+	client env_pb.EnvironmentManagerClient
+}
+
+type K8sAgentManifestDataSourceModel struct {
+	Runtime types.String `tfsdk:"runtime"`
+	Id      types.String `tfsdk:"id"`
+
+	Agent *agentManifestSettingsModel `tfsdk:"agent"`
+
+	Manifest types.String                   `tfsdk:"manifest"`
+	Objects  []*k8sAgentManifestObjectModel `tfsdk:"objects"`
+}
+
+// agentManifestSettingsModel is the subset of agentModel that makes sense
+// without knowing how the agent is installed: its namespace and scheduling.
+type agentManifestSettingsModel struct {
+	Namespace         types.String         `tfsdk:"namespace"`
+	Resources         *agentResourcesModel `tfsdk:"resources"`
+	NodeSelector      types.Map            `tfsdk:"node_selector"`
+	Tolerations       []*agentToleration   `tfsdk:"tolerations"`
+	Affinity          types.String         `tfsdk:"affinity"`
+	PriorityClassName types.String         `tfsdk:"priority_class_name"`
+}
+
+type k8sAgentManifestObjectModel struct {
+	ApiVersion types.String `tfsdk:"api_version"`
+	Kind       types.String `tfsdk:"kind"`
+	Name       types.String `tfsdk:"name"`
+	Namespace  types.String `tfsdk:"namespace"`
+	Manifest   types.String `tfsdk:"manifest"`
+}
+
+func (d *K8sAgentManifestDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_agent_manifest"
+}
+
+func (d *K8sAgentManifestDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Renders the Kubernetes objects that install the Prodvana agent of an existing Kubernetes [Runtime](https://docs.prodvana.io/docs/prodvana-concepts#runtime), the same objects `prodvana_managed_k8s_runtime` applies. Use it to install the agent with `kubectl_manifest`, Helm or a GitOps tool instead of writing the deployment by hand.\n\n" +
+			"Prodvana only returns the agent image and arguments when a runtime is linked, so reading this data source re-links the runtime with its current auth and config. Nothing about the runtime changes, but every read, including `terraform plan`, is a write to Prodvana and needs permission to link runtimes.",
+		Attributes: map[string]schema.Attribute{
+			"runtime": schema.StringAttribute{
+				MarkdownDescription: "Name of the Kubernetes runtime to render the agent for",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Runtime identifier",
+				Computed:            true,
+			},
+			"agent": schema.SingleNestedAttribute{
+				MarkdownDescription: "Customizes where the agent is installed and scheduled, like the `agent` block of `prodvana_managed_k8s_runtime`",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"namespace": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Namespace to install the agent in. Defaults to `%s`", defaultAgentNamespace),
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthBetween(1, 63),
+						},
+					},
+					"resources": schema.SingleNestedAttribute{
+						MarkdownDescription: "Compute resources of the agent container",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"requests": schema.MapAttribute{
+								MarkdownDescription: "Resource requests, e.g. `{ cpu = \"100m\", memory = \"128Mi\" }`",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+							"limits": schema.MapAttribute{
+								MarkdownDescription: "Resource limits, e.g. `{ memory = \"512Mi\" }`",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+						},
+					},
+					"node_selector": schema.MapAttribute{
+						MarkdownDescription: "Node labels the agent pod must be scheduled on",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"tolerations": schema.ListNestedAttribute{
+						MarkdownDescription: "Tolerations of the agent pod",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"key": schema.StringAttribute{
+									MarkdownDescription: "Taint key the toleration applies to, empty matches all keys",
+									Optional:            true,
+								},
+								"operator": schema.StringAttribute{
+									MarkdownDescription: "`Equal` or `Exists`. Defaults to `Equal`",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf(string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists)),
+									},
+								},
+								"value": schema.StringAttribute{
+									MarkdownDescription: "Taint value the toleration matches",
+									Optional:            true,
+								},
+								"effect": schema.StringAttribute{
+									MarkdownDescription: "Taint effect to match, `NoSchedule`, `PreferNoSchedule` or `NoExecute`, empty matches all effects",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.OneOf(string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)),
+									},
+								},
+								"toleration_seconds": schema.Int64Attribute{
+									MarkdownDescription: "How long a `NoExecute` toleration tolerates the taint",
+									Optional:            true,
+								},
+							},
+						},
+					},
+					"affinity": schema.StringAttribute{
+						MarkdownDescription: "Affinity of the agent pod, as a JSON or YAML encoded Kubernetes `Affinity`, e.g. `jsonencode({ nodeAffinity = { ... } })`",
+						Optional:            true,
+					},
+					"priority_class_name": schema.StringAttribute{
+						MarkdownDescription: "Priority class of the agent pod",
+						Optional:            true,
+					},
+				},
+			},
+			"manifest": schema.StringAttribute{
+				MarkdownDescription: "Multi-document YAML manifest of the agent namespace, service account, RBAC objects and deployment, in the order they must be applied in",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"objects": schema.ListNestedAttribute{
+				MarkdownDescription: "The agent objects of `manifest`, one per document, e.g. to create one `kubectl_manifest` per object",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"api_version": schema.StringAttribute{
+							MarkdownDescription: "API version of the object",
+							Computed:            true,
+						},
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "Kind of the object",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the object",
+							Computed:            true,
+						},
+						"namespace": schema.StringAttribute{
+							MarkdownDescription: "Namespace of the object, empty for cluster scoped objects",
+							Computed:            true,
+						},
+						"manifest": schema.StringAttribute{
+							MarkdownDescription: "YAML manifest of the object",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *K8sAgentManifestDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.Environment
+}
+
+// toAgentModel fills the agent attributes the data source does not expose
+// with their defaults.
+func (m *agentManifestSettingsModel) toAgentModel() *agentModel {
+	if m == nil {
+		return nil
+	}
+	return &agentModel{
+		Namespace:          m.Namespace,
+		Resources:          m.Resources,
+		NodeSelector:       m.NodeSelector,
+		Tolerations:        m.Tolerations,
+		Affinity:           m.Affinity,
+		PriorityClassName:  m.PriorityClassName,
+		PodSecurityContext: types.StringNull(),
+		SecurityContext:    types.StringNull(),
+		Labels:             types.MapNull(types.StringType),
+		Annotations:        types.MapNull(types.StringType),
+		ImagePullSecrets:   types.ListNull(types.StringType),
+	}
+}
+
+func (d *K8sAgentManifestDataSource) read(ctx context.Context, diags *diag.Diagnostics, data *K8sAgentManifestDataSourceModel) error {
+	opts, optsDiags := agentOptionsFromModel(ctx, data.Agent.toAgentModel())
+	diags.Append(optsDiags...)
+	if diags.HasError() {
+		return errors.Errorf("Failed to convert agent: %v", diags.Errors())
+	}
+
+	getResp, err := d.client.GetCluster(ctx, &env_pb.GetClusterReq{
+		Runtime:     data.Runtime.ValueString(),
+		IncludeAuth: true,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read runtime state for %s", data.Runtime.ValueString())
+	}
+	cluster := getResp.Cluster
+	if cluster.Type != env_pb.ClusterType_K8S {
+		return errors.Errorf("Unexpected non-Kubernetes runtime type: %s", cluster.Type.String())
+	}
+
+	// only linking returns the agent image and arguments, link the runtime
+	// again with its current auth and config so nothing about it changes.
+	// Source is left unset, Cluster does not expose the source the runtime was
+	// last linked with and reading must not claim it for Terraform.
+	auth := cluster.GetAuth()
+	if auth == nil || auth.GetK8S() == nil {
+		auth = &env_pb.ClusterAuth{
+			AuthOneof: &env_pb.ClusterAuth_K8S{
+				K8S: &env_pb.ClusterAuth_K8SAuth{
+					AgentExternallyManaged: true,
+				},
+			},
+		}
+	}
+	linkResp, err := d.client.LinkCluster(ctx, &env_pb.LinkClusterReq{
+		Name:   cluster.Name,
+		Type:   env_pb.ClusterType_K8S,
+		Auth:   auth,
+		Config: cluster.GetConfig(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read agent configuration for %s", cluster.Name)
+	}
+
+	opts.runtimeId = linkResp.ClusterId
+	opts.image = linkResp.K8SAgentImage
+	opts.args = linkResp.K8SAgentArgs
+	opts.env = auth.GetK8S().AgentEnv
+	objects := buildAgentObjects(opts)
+
+	manifest, err := renderAgentManifests(objects)
+	if err != nil {
+		return err
+	}
+	manifests, err := agentManifests(objects)
+	if err != nil {
+		return err
+	}
+	data.Objects = nil
+	for _, m := range manifests {
+		document, err := renderAgentManifest(m)
+		if err != nil {
+			return err
+		}
+		obj := unstructured.Unstructured{Object: m}
+		data.Objects = append(data.Objects, &k8sAgentManifestObjectModel{
+			ApiVersion: types.StringValue(obj.GetAPIVersion()),
+			Kind:       types.StringValue(obj.GetKind()),
+			Name:       types.StringValue(obj.GetName()),
+			Namespace:  types.StringValue(obj.GetNamespace()),
+			Manifest:   types.StringValue(document),
+		})
+	}
+
+	data.Id = types.StringValue(linkResp.ClusterId)
+	data.Manifest = types.StringValue(manifest)
+	return nil
+}
+
+func (d *K8sAgentManifestDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data K8sAgentManifestDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := d.read(ctx, &resp.Diagnostics, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to render agent manifest for %s, got error: %s", data.Runtime.ValueString(), err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccK8sAgentManifestDataSource(t *testing.T) {
+	runtimeName := uniqueTestName("agent-manifest-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccK8sAgentManifestDataSourceConfig(runtimeName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.prodvana_k8s_agent_manifest.test", "id", "prodvana_k8s_runtime.test", "id"),
+					resource.TestCheckResourceAttrSet("data.prodvana_k8s_agent_manifest.test", "manifest"),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.#", "4"),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.0.kind", "Namespace"),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.0.name", "prodvana-agent"),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.2.kind", "ClusterRoleBinding"),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.2.namespace", ""),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.3.kind", "Deployment"),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.3.api_version", "apps/v1"),
+					resource.TestCheckResourceAttr("data.prodvana_k8s_agent_manifest.test", "objects.3.namespace", "prodvana-agent"),
+					resource.TestCheckResourceAttrSet("data.prodvana_k8s_agent_manifest.test", "objects.3.manifest"),
+				),
+			},
+		},
+	})
+}
+
+func testAccK8sAgentManifestDataSourceConfig(runtimeName string) string {
+	return fmt.Sprintf(`
+resource "prodvana_k8s_runtime" "test" {
+  name = %[1]q
+}
+
+data "prodvana_k8s_agent_manifest" "test" {
+  runtime = prodvana_k8s_runtime.test.name
+  agent = {
+    namespace = "prodvana-agent"
+    node_selector = {
+      "kubernetes.io/os" = "linux"
+    }
+  }
+}
+`, runtimeName)
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// agentManifestObjects lists the agent objects in the order they must be
+// applied in, the namespace first and the deployment last.
+func agentManifestObjects(objects *agentObjects) []runtime.Object {
+	list := []runtime.Object{objects.namespace, objects.serviceAccount}
+	if objects.clusterRole != nil {
+		list = append(list, objects.clusterRole)
+	}
+	if objects.clusterRoleBinding != nil {
+		list = append(list, objects.clusterRoleBinding)
+	}
+	for _, role := range objects.roles {
+		list = append(list, role)
+	}
+	for _, roleBinding := range objects.roleBindings {
+		list = append(list, roleBinding)
+	}
+	return append(list, objects.deployment)
+}
+
+// agentManifests converts the agent objects to their manifests, without the
+// fields only the API server fills in.
+func agentManifests(objects *agentObjects) ([]map[string]interface{}, error) {
+	var manifests []map[string]interface{}
+	for _, obj := range agentManifestObjects(objects) {
+		manifest, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to convert %s", obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+		unstructured.RemoveNestedField(manifest, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(manifest, "spec", "template", "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(manifest, "status")
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+// renderAgentManifests renders the agent objects as a multi-document YAML
+// manifest, for installs through kubectl, Helm or a GitOps tool.
+func renderAgentManifests(objects *agentObjects) (string, error) {
+	manifests, err := agentManifests(objects)
+	if err != nil {
+		return "", err
+	}
+	var documents []string
+	for _, manifest := range manifests {
+		document, err := renderAgentManifest(manifest)
+		if err != nil {
+			return "", err
+		}
+		documents = append(documents, document)
+	}
+	return strings.Join(documents, "---\n"), nil
+}
+
+func renderAgentManifest(manifest map[string]interface{}) (string, error) {
+	document, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to render manifest")
+	}
+	return string(document), nil
+}
+
+// defaultAgentManifests renders the agent objects with the default options,
+// for runtimes whose agent is installed outside Terraform.
+func defaultAgentManifests(runtimeId, image string, args []string) (string, error) {
+	opts, _ := agentOptionsFromModel(context.Background(), nil)
+	opts.runtimeId = runtimeId
+	opts.image = image
+	opts.args = args
+	return renderAgentManifests(buildAgentObjects(opts))
+}
+
+// writeAgentManifests writes the rendered manifests to path, readable only by
+// the owner as the agent arguments contain its API token.
+func writeAgentManifests(path, manifests string) error {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(expanded), 0755); err != nil {
+		return errors.Wrapf(err, "Failed to create directory for %s", path)
+	}
+	if err := os.WriteFile(expanded, []byte(manifests), 0600); err != nil {
+		return errors.Wrapf(err, "Failed to write manifests to %s", path)
+	}
+	return nil
+}
+
+// agentManifestsExist returns whether the manifests written to path are still there.
+func agentManifestsExist(path string) (bool, error) {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(expanded)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to read manifests at %s", path)
+	}
+	return true, nil
+}
+
+func removeAgentManifests(path string) error {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(expanded); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "Failed to remove manifests at %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestRenderAgentManifests(t *testing.T) {
+	ctx := context.Background()
+	agent := testAgentModel()
+	agent.Rbac = testAgentRbac(agentRbacModeNamespaced, []string{"apps"}, "")
+	opts, diags := agentOptionsFromModel(ctx, agent)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	opts.runtimeId = "runtime-id"
+	opts.image = "agent:latest"
+	opts.args = []string{"--token", "secret"}
+
+	manifests, err := renderAgentManifests(buildAgentObjects(opts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(manifests, "creationTimestamp") || strings.Contains(manifests, "status:") {
+		t.Errorf("expected fields set by the API server to be left out, got:\n%s", manifests)
+	}
+
+	var kinds []string
+	for _, document := range strings.Split(manifests, "---\n") {
+		var manifest map[string]interface{}
+		if err := yaml.Unmarshal([]byte(document), &manifest); err != nil {
+			t.Fatalf("unable to parse %q: %s", document, err)
+		}
+		if manifest["apiVersion"] == nil {
+			t.Errorf("expected an apiVersion in %q", document)
+		}
+		kinds = append(kinds, manifest["kind"].(string))
+	}
+	expected := []string{"Namespace", "ServiceAccount", "Role", "Role", "RoleBinding", "RoleBinding", "Deployment"}
+	if strings.Join(kinds, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected kinds %v, got %v", expected, kinds)
+	}
+	if !strings.Contains(manifests, "agent:latest") || !strings.Contains(manifests, "runtime-id") {
+		t.Errorf("expected the deployment to use the runtime image and id, got:\n%s", manifests)
+	}
+}
+
+func TestDefaultAgentManifests(t *testing.T) {
+	manifests, err := defaultAgentManifests("runtime-id", "agent:latest", []string{"--token", "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(manifests, "---\n") + 1; got != 4 {
+		t.Errorf("expected 4 documents, got %d:\n%s", got, manifests)
+	}
+	if !strings.Contains(manifests, "name: cluster-admin") || !strings.Contains(manifests, "namespace: "+defaultAgentNamespace) {
+		t.Errorf("expected the default namespace bound to cluster-admin, got:\n%s", manifests)
+	}
+}
+
+func TestWriteAgentManifests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prodvana", "agent.yaml")
+	if err := writeAgentManifests(path, "kind: Namespace\n"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected the manifests to only be readable by the owner, got %s", info.Mode().Perm())
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "kind: Namespace\n" {
+		t.Errorf("unexpected content %q", content)
+	}
+
+	if err := removeAgentManifests(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the manifests to be removed, got %v", err)
+	}
+	// already removed
+	if err := removeAgentManifests(path); err != nil {
+		t.Errorf("expected removing missing manifests to succeed, got %s", err)
+	}
+}
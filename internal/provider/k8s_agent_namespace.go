@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// how many remaining objects of each kind are listed when a namespace is stuck
+const namespaceRemainingObjectLimit = 10
+
+func namespaceListWatch(clientSet kubernetes.Interface, namespace string) cache.ListerWatcher {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", namespace).String()
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return clientSet.CoreV1().Namespaces().List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return clientSet.CoreV1().Namespaces().Watch(context.Background(), options)
+		},
+	}
+}
+
+// waitForNamespaceDeletion waits until the namespace is gone, explaining what
+// is left in it when it is not within timeout.
+func waitForNamespaceDeletion(ctx context.Context, clientSet kubernetes.Interface, namespace string, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	gone := func(store cache.Store) (bool, error) {
+		_, exists, err := store.GetByKey(namespace)
+		return !exists, err
+	}
+	_, err := watchtools.UntilWithSync(waitCtx, namespaceListWatch(clientSet, namespace), &corev1.Namespace{}, gone, func(event watch.Event) (bool, error) {
+		ns, ok := event.Object.(*corev1.Namespace)
+		return ok && ns.Name == namespace && event.Type == watch.Deleted, nil
+	})
+	if err == nil {
+		return nil
+	}
+	// the wait can also end while the watch cache is still syncing
+	if ctx.Err() != nil {
+		return errors.Wrapf(ctx.Err(), "Stopped waiting for namespace %s to be deleted", namespace)
+	}
+	if err == wait.ErrWaitTimeout || waitCtx.Err() != nil {
+		return errors.Errorf("Namespace %s was not deleted after %s, set keep_namespace to leave it in place:\n%s", namespace, timeout, namespaceDeletionDiagnostics(ctx, clientSet, namespace))
+	}
+	return errors.Wrapf(err, "Failed to wait for namespace %s to be deleted", namespace)
+}
+
+// namespaceDeletionDiagnostics describes why a namespace is stuck terminating:
+// its finalizers, the conditions the namespace controller reports about the
+// content it could not remove, and the remaining objects that have finalizers.
+func namespaceDeletionDiagnostics(ctx context.Context, clientSet kubernetes.Interface, namespace string) string {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	var lines []string
+
+	ns, err := clientSet.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Sprintf("Unable to read namespace %s: %s", namespace, err)
+	}
+	lines = append(lines, fmt.Sprintf("Namespace %s: %s", namespace, ns.Status.Phase))
+	var finalizers []string
+	for _, finalizer := range ns.Spec.Finalizers {
+		finalizers = append(finalizers, string(finalizer))
+	}
+	finalizers = append(finalizers, ns.Finalizers...)
+	if len(finalizers) > 0 {
+		lines = append(lines, fmt.Sprintf("  finalizers: %s", strings.Join(finalizers, ", ")))
+	}
+	for _, condition := range ns.Status.Conditions {
+		if condition.Status == corev1.ConditionTrue {
+			lines = append(lines, fmt.Sprintf("  %s: %s", condition.Type, condition.Message))
+		}
+	}
+
+	remaining := func(kind string, list func() ([]metav1.Object, error)) {
+		objects, err := list()
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("Unable to list %s: %s", kind, err))
+			return
+		}
+		count := 0
+		for _, obj := range objects {
+			if len(obj.GetFinalizers()) == 0 {
+				continue
+			}
+			count++
+			if count <= namespaceRemainingObjectLimit {
+				lines = append(lines, fmt.Sprintf("  %s/%s finalizers: %s", kind, obj.GetName(), strings.Join(obj.GetFinalizers(), ", ")))
+			}
+		}
+		if count > namespaceRemainingObjectLimit {
+			lines = append(lines, fmt.Sprintf("  and %d more %s with finalizers", count-namespaceRemainingObjectLimit, kind))
+		}
+	}
+	remaining("pod", func() ([]metav1.Object, error) {
+		list, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		var objects []metav1.Object
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+		return objects, nil
+	})
+	remaining("persistentvolumeclaim", func() ([]metav1.Object, error) {
+		list, err := clientSet.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		var objects []metav1.Object
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+		return objects, nil
+	})
+	remaining("service", func() ([]metav1.Object, error) {
+		list, err := clientSet.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		var objects []metav1.Object
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+		return objects, nil
+	})
+	remaining("deployment", func() ([]metav1.Object, error) {
+		list, err := clientSet.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		var objects []metav1.Object
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+		return objects, nil
+	})
+	return strings.Join(lines, "\n")
+}
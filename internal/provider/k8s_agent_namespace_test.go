@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8s_testing "k8s.io/client-go/testing"
+)
+
+func TestWaitForNamespaceDeletion(t *testing.T) {
+	ctx := context.Background()
+
+	// already gone
+	if err := waitForNamespaceDeletion(ctx, fake.NewSimpleClientset(), defaultAgentNamespace, 5*time.Second); err != nil {
+		t.Errorf("expected a missing namespace to be deleted, got %s", err)
+	}
+
+	// deleted while waiting, other namespaces are ignored
+	clientSet := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: defaultAgentNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = clientSet.CoreV1().Namespaces().Delete(ctx, "other", metav1.DeleteOptions{})
+		time.Sleep(200 * time.Millisecond)
+		_ = clientSet.CoreV1().Namespaces().Delete(ctx, defaultAgentNamespace, metav1.DeleteOptions{})
+	}()
+	start := time.Now()
+	if err := waitForNamespaceDeletion(ctx, clientSet, defaultAgentNamespace, 5*time.Second); err != nil {
+		t.Errorf("expected the namespace to be deleted, got %s", err)
+	}
+	if time.Since(start) < 400*time.Millisecond {
+		t.Errorf("expected to wait for the agent namespace, not the other one")
+	}
+}
+
+func TestWaitForNamespaceDeletionStuck(t *testing.T) {
+	ctx := context.Background()
+	clientSet := fake.NewSimpleClientset(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultAgentNamespace},
+			Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+			Status: corev1.NamespaceStatus{
+				Phase: corev1.NamespaceTerminating,
+				Conditions: []corev1.NamespaceCondition{
+					{Type: corev1.NamespaceFinalizersRemaining, Status: corev1.ConditionTrue, Message: "Some content in the namespace has finalizers remaining: example.com/cleanup in 1 resource instances"},
+					{Type: corev1.NamespaceDeletionDiscoveryFailure, Status: corev1.ConditionFalse, Message: "All resources successfully discovered"},
+				},
+			},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: defaultAgentNamespace, Finalizers: []string{"example.com/cleanup"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: defaultAgentNamespace}},
+	)
+	// the namespace controller cannot finish, deleting keeps the namespace around
+	clientSet.PrependReactor("delete", "namespaces", func(action k8s_testing.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	err := deleteKubernetesObjects(ctx, &agentObjects{namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: defaultAgentNamespace}}}, clientSet, 500*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected a stuck namespace to time out")
+	}
+	for _, expected := range []string{
+		"Namespace prodvana was not deleted after 500ms",
+		"finalizers: kubernetes",
+		"NamespaceFinalizersRemaining: Some content in the namespace has finalizers remaining: example.com/cleanup",
+		"pod/stuck finalizers: example.com/cleanup",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in the error, got:\n%s", expected, err)
+		}
+	}
+	for _, unexpected := range []string{"NamespaceDeletionDiscoveryFailure", "pod/done"} {
+		if strings.Contains(err.Error(), unexpected) {
+			t.Errorf("expected no %q in the error, got:\n%s", unexpected, err)
+		}
+	}
+
+	// cancelled by Terraform
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = waitForNamespaceDeletion(cancelled, clientSet, defaultAgentNamespace, 5*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "Stopped waiting for namespace prodvana to be deleted") {
+		t.Errorf("expected waiting to stop with the context, got %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// bind the agent to the built-in cluster-admin ClusterRole
+	agentRbacModeClusterAdmin = "cluster-admin"
+	// create a ClusterRole from agentClusterRules and bind the agent to it
+	agentRbacModeScoped = "scoped"
+	// create a Role from agentNamespaceRules in each listed namespace
+	agentRbacModeNamespaced = "namespaced"
+	// bind the agent to a ClusterRole managed outside Terraform
+	agentRbacModeExisting = "existing"
+
+	// name of the ClusterRole, Roles and RoleBindings created for the agent
+	agentRoleName = "prodvana-access"
+)
+
+var agentRbacModes = []string{
+	agentRbacModeClusterAdmin,
+	agentRbacModeScoped,
+	agentRbacModeNamespaced,
+	agentRbacModeExisting,
+}
+
+var allVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}
+var readVerbs = []string{"get", "list", "watch"}
+
+// agentNamespaceRules is what the agent needs to deploy and observe
+// workloads in a namespace.
+var agentNamespaceRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"configmaps", "endpoints", "persistentvolumeclaims", "pods", "secrets", "serviceaccounts", "services"},
+		Verbs:     allVerbs,
+	},
+	{
+		APIGroups: []string{""},
+		Resources: []string{"events", "pods/log", "pods/status"},
+		Verbs:     readVerbs,
+	},
+	{
+		APIGroups: []string{"apps"},
+		Resources: []string{"daemonsets", "deployments", "replicasets", "statefulsets"},
+		Verbs:     allVerbs,
+	},
+	{
+		APIGroups: []string{"batch"},
+		Resources: []string{"cronjobs", "jobs"},
+		Verbs:     allVerbs,
+	},
+	{
+		APIGroups: []string{"autoscaling"},
+		Resources: []string{"horizontalpodautoscalers"},
+		Verbs:     allVerbs,
+	},
+	{
+		APIGroups: []string{"policy"},
+		Resources: []string{"poddisruptionbudgets"},
+		Verbs:     allVerbs,
+	},
+	{
+		APIGroups: []string{"networking.k8s.io"},
+		Resources: []string{"ingresses", "networkpolicies"},
+		Verbs:     allVerbs,
+	},
+	{
+		APIGroups: []string{"coordination.k8s.io"},
+		Resources: []string{"leases"},
+		Verbs:     allVerbs,
+	},
+}
+
+// agentClusterRules extends agentNamespaceRules with the cluster wide
+// access the agent needs to manage namespaces and report on the cluster.
+var agentClusterRules = append([]rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"namespaces"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch"},
+	},
+	{
+		APIGroups: []string{""},
+		Resources: []string{"nodes"},
+		Verbs:     readVerbs,
+	},
+	{
+		APIGroups: []string{"apiextensions.k8s.io"},
+		Resources: []string{"customresourcedefinitions"},
+		Verbs:     readVerbs,
+	},
+}, agentNamespaceRules...)
+
+type agentRbacModel struct {
+	Mode        types.String `tfsdk:"mode"`
+	Namespaces  types.List   `tfsdk:"namespaces"`
+	ClusterRole types.String `tfsdk:"cluster_role"`
+}
+
+func agentRbacSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Permissions granted to the agent service account. Defaults to binding the agent to `cluster-admin`",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"mode": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf(`How the agent is granted access, one of:
+  - %[1]s: bind the agent to the built-in %[1]s ClusterRole (default)
+  - %[2]s: create a %[5]s ClusterRole limited to the rules shipped with the provider
+  - %[3]s: create a %[5]s Role and RoleBinding in each namespace of %[6]s and in the agent namespace, no cluster wide access is granted
+  - %[4]s: bind the agent to the ClusterRole named by %[7]s, which is managed outside Terraform`,
+					"`"+agentRbacModeClusterAdmin+"`", "`"+agentRbacModeScoped+"`", "`"+agentRbacModeNamespaced+"`", "`"+agentRbacModeExisting+"`",
+					"`"+agentRoleName+"`", "`namespaces`", "`cluster_role`"),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(agentRbacModes...),
+				},
+			},
+			"namespaces": schema.ListAttribute{
+				MarkdownDescription: fmt.Sprintf("Namespaces the agent may manage, required when `mode` is `%s`", agentRbacModeNamespaced),
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"cluster_role": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Name of an existing ClusterRole to bind the agent to, required when `mode` is `%s`", agentRbacModeExisting),
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// agentRbacOptionsFromModel fills the rbac fields of opts, reporting
+// attributes that do not match the selected mode.
+func agentRbacOptionsFromModel(ctx context.Context, rbac *agentRbacModel, opts *agentOptions, diags *diag.Diagnostics) {
+	opts.rbacMode = agentRbacModeClusterAdmin
+	if rbac == nil {
+		return
+	}
+	rbacPath := path.Root("agent").AtName("rbac")
+	if rbac.Mode.IsUnknown() {
+		return
+	}
+	if !rbac.Mode.IsNull() {
+		opts.rbacMode = rbac.Mode.ValueString()
+	}
+
+	if !rbac.Namespaces.IsNull() && !rbac.Namespaces.IsUnknown() {
+		diags.Append(rbac.Namespaces.ElementsAs(ctx, &opts.rbacNamespaces, false)...)
+	}
+	opts.clusterRole = rbac.ClusterRole.ValueString()
+
+	switch opts.rbacMode {
+	case agentRbacModeNamespaced:
+		if len(opts.rbacNamespaces) == 0 && !rbac.Namespaces.IsUnknown() {
+			diags.AddAttributeError(rbacPath.AtName("namespaces"), "Missing Namespaces", fmt.Sprintf("`namespaces` must list at least one namespace when `mode` is `%s`", agentRbacModeNamespaced))
+		}
+	default:
+		if !rbac.Namespaces.IsNull() {
+			diags.AddAttributeError(rbacPath.AtName("namespaces"), "Invalid Attribute Combination", fmt.Sprintf("`namespaces` can only be set when `mode` is `%s`", agentRbacModeNamespaced))
+		}
+	}
+	switch opts.rbacMode {
+	case agentRbacModeExisting:
+		if rbac.ClusterRole.IsNull() {
+			diags.AddAttributeError(rbacPath.AtName("cluster_role"), "Missing Cluster Role", fmt.Sprintf("`cluster_role` must be set when `mode` is `%s`", agentRbacModeExisting))
+		}
+	default:
+		if !rbac.ClusterRole.IsNull() {
+			diags.AddAttributeError(rbacPath.AtName("cluster_role"), "Invalid Attribute Combination", fmt.Sprintf("`cluster_role` can only be set when `mode` is `%s`", agentRbacModeExisting))
+		}
+	}
+}
+
+// agentRoleNamespaces returns the namespaces that get a Role in namespaced
+// mode, the agent namespace first and without duplicates.
+func agentRoleNamespaces(opts *agentOptions) []string {
+	namespaces := []string{opts.namespace}
+	seen := map[string]bool{opts.namespace: true}
+	for _, ns := range opts.rbacNamespaces {
+		if seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// buildAgentRbacObjects fills the RBAC objects of objects for the mode in opts.
+func buildAgentRbacObjects(opts *agentOptions, objects *agentObjects) {
+	subjects := []rbacv1.Subject{
+		{
+			Kind:      "ServiceAccount",
+			Name:      objects.serviceAccount.Name,
+			Namespace: objects.serviceAccount.Namespace,
+		},
+	}
+	clusterRoleBinding := func(clusterRole string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "rbac.authorization.k8s.io/v1",
+				Kind:       "ClusterRoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterRoleBindingName,
+			},
+			Subjects: subjects,
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     clusterRole,
+			},
+		}
+	}
+
+	switch opts.rbacMode {
+	case agentRbacModeScoped:
+		objects.clusterRole = &rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "rbac.authorization.k8s.io/v1",
+				Kind:       "ClusterRole",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: agentRoleName,
+			},
+			Rules: agentClusterRules,
+		}
+		objects.clusterRoleBinding = clusterRoleBinding(agentRoleName)
+	case agentRbacModeNamespaced:
+		for _, ns := range agentRoleNamespaces(opts) {
+			objects.roles = append(objects.roles, &rbacv1.Role{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "rbac.authorization.k8s.io/v1",
+					Kind:       "Role",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      agentRoleName,
+					Namespace: ns,
+				},
+				Rules: agentNamespaceRules,
+			})
+			objects.roleBindings = append(objects.roleBindings, &rbacv1.RoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "rbac.authorization.k8s.io/v1",
+					Kind:       "RoleBinding",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      agentRoleName,
+					Namespace: ns,
+				},
+				Subjects: subjects,
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: "rbac.authorization.k8s.io",
+					Kind:     "Role",
+					Name:     agentRoleName,
+				},
+			})
+		}
+	case agentRbacModeExisting:
+		objects.clusterRoleBinding = clusterRoleBinding(opts.clusterRole)
+	default:
+		objects.clusterRoleBinding = clusterRoleBinding("cluster-admin")
+	}
+}
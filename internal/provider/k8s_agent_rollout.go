@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// container waiting reasons that will not resolve without a change to the
+// deployment, the rollout is failed right away instead of waiting for the timeout
+var agentPodFailureReasons = map[string]bool{
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// how many of the most recent events are included when a rollout fails
+const agentRolloutEventLimit = 10
+
+// deploymentRolledOut reports whether every replica of the deployment runs its latest spec and is available.
+func deploymentRolledOut(deployment *appsv1.Deployment) bool {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	var replicas int32 = 1
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.UpdatedReplicas >= replicas && status.AvailableReplicas >= replicas && status.Replicas == status.UpdatedReplicas
+}
+
+// deploymentFailure returns why the deployment controller gave up on the rollout, if it did.
+func deploymentFailure(deployment *appsv1.Deployment) string {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse && condition.Reason == "ProgressDeadlineExceeded" {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+// podFailure returns why a container of the pod cannot start, if it is stuck.
+func podFailure(pod *corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting != nil && agentPodFailureReasons[waiting.Reason] {
+			return fmt.Sprintf("container %s of pod %s is in %s: %s", status.Name, pod.Name, waiting.Reason, waiting.Message)
+		}
+	}
+	return ""
+}
+
+func agentDeploymentListWatch(clientSet kubernetes.Interface, namespace string) cache.ListerWatcher {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", agentDeploymentName).String()
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return clientSet.AppsV1().Deployments(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return clientSet.AppsV1().Deployments(namespace).Watch(context.Background(), options)
+		},
+	}
+}
+
+func agentPodListWatch(clientSet kubernetes.Interface, namespace string) cache.ListerWatcher {
+	labelSelector := fmt.Sprintf("app=%s", agentDeploymentName)
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = labelSelector
+			return clientSet.CoreV1().Pods(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = labelSelector
+			return clientSet.CoreV1().Pods(namespace).Watch(context.Background(), options)
+		},
+	}
+}
+
+// watchAgentDeployment blocks until the agent deployment rolled out, its
+// rollout failed, or ctx is done.
+func watchAgentDeployment(ctx context.Context, clientSet kubernetes.Interface, namespace string) error {
+	_, err := watchtools.UntilWithSync(ctx, agentDeploymentListWatch(clientSet, namespace), &appsv1.Deployment{}, nil, func(event watch.Event) (bool, error) {
+		deployment, ok := event.Object.(*appsv1.Deployment)
+		if !ok || deployment.Name != agentDeploymentName {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, errors.New("agent deployment was deleted")
+		}
+		if failure := deploymentFailure(deployment); failure != "" {
+			return false, errors.Errorf("agent deployment rollout failed: %s", failure)
+		}
+		return deploymentRolledOut(deployment), nil
+	})
+	return err
+}
+
+// watchAgentPods blocks until a pod created since since is stuck, which is
+// returned as error, or ctx is done.
+func watchAgentPods(ctx context.Context, clientSet kubernetes.Interface, namespace string, since time.Time) error {
+	var failure error
+	// the watch only ends early on a failure, anything else means ctx is done
+	_, _ = watchtools.UntilWithSync(ctx, agentPodListWatch(clientSet, namespace), &corev1.Pod{}, nil, func(event watch.Event) (bool, error) {
+		pod, ok := event.Object.(*corev1.Pod)
+		if !ok || event.Type == watch.Deleted || pod.CreationTimestamp.Time.Before(since) {
+			return false, nil
+		}
+		if reason := podFailure(pod); reason != "" {
+			failure = errors.New(reason)
+			return true, nil
+		}
+		return false, nil
+	})
+	return failure
+}
+
+// waitForAgentRollout waits until the agent deployment finished rolling out,
+// failing early if one of the new agent pods cannot start.
+func waitForAgentRollout(ctx context.Context, clientSet kubernetes.Interface, namespace string, timeout time.Duration) error {
+	// creation timestamps only have second precision
+	since := time.Now().Truncate(time.Second)
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	podFailures := make(chan error, 1)
+	go func() {
+		defer close(podFailures)
+		if err := watchAgentPods(waitCtx, clientSet, namespace, since); err != nil {
+			podFailures <- err
+			cancel()
+		}
+	}()
+
+	err := watchAgentDeployment(waitCtx, clientSet, namespace)
+	cancel()
+	if podErr := <-podFailures; podErr != nil {
+		err = podErr
+	} else if err == wait.ErrWaitTimeout {
+		if ctx.Err() != nil {
+			return errors.Wrap(ctx.Err(), "Stopped waiting for the agent deployment")
+		}
+		err = errors.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		return errors.Errorf("Agent deployment %s/%s did not become ready: %s\n%s", namespace, agentDeploymentName, err, agentRolloutDiagnostics(ctx, clientSet, namespace))
+	}
+	return nil
+}
+
+// agentRolloutDiagnostics describes the agent deployment, its pods and the
+// recent events in the agent namespace, to explain a failed rollout.
+func agentRolloutDiagnostics(ctx context.Context, clientSet kubernetes.Interface, namespace string) string {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	var lines []string
+
+	deployment, err := clientSet.AppsV1().Deployments(namespace).Get(ctx, agentDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("Unable to read the agent deployment: %s", err))
+	} else {
+		lines = append(lines, fmt.Sprintf("Deployment: %d updated, %d available, %d total replicas", deployment.Status.UpdatedReplicas, deployment.Status.AvailableReplicas, deployment.Status.Replicas))
+		for _, condition := range deployment.Status.Conditions {
+			lines = append(lines, fmt.Sprintf("  %s=%s %s: %s", condition.Type, condition.Status, condition.Reason, condition.Message))
+		}
+	}
+
+	pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=%s", agentDeploymentName),
+	})
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("Unable to list the agent pods: %s", err))
+	} else {
+		for _, pod := range pods.Items {
+			lines = append(lines, fmt.Sprintf("Pod %s: %s", pod.Name, pod.Status.Phase))
+			for _, condition := range pod.Status.Conditions {
+				if condition.Status != corev1.ConditionTrue && condition.Reason != "" {
+					lines = append(lines, fmt.Sprintf("  %s=%s %s: %s", condition.Type, condition.Status, condition.Reason, condition.Message))
+				}
+			}
+			for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+				switch {
+				case status.State.Waiting != nil:
+					lines = append(lines, fmt.Sprintf("  container %s waiting: %s %s", status.Name, status.State.Waiting.Reason, status.State.Waiting.Message))
+				case status.State.Terminated != nil:
+					lines = append(lines, fmt.Sprintf("  container %s terminated: %s (exit code %d) %s", status.Name, status.State.Terminated.Reason, status.State.Terminated.ExitCode, status.State.Terminated.Message))
+				case !status.Ready:
+					lines = append(lines, fmt.Sprintf("  container %s not ready, %d restarts", status.Name, status.RestartCount))
+				}
+			}
+		}
+	}
+
+	events, err := clientSet.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		lines = append(lines, fmt.Sprintf("Unable to list events: %s", err))
+	} else if len(events.Items) > 0 {
+		items := events.Items
+		sort.Slice(items, func(i, j int) bool {
+			return eventTime(items[i]).Before(eventTime(items[j]))
+		})
+		if len(items) > agentRolloutEventLimit {
+			items = items[len(items)-agentRolloutEventLimit:]
+		}
+		lines = append(lines, "Recent events:")
+		for _, event := range items {
+			lines = append(lines, fmt.Sprintf("  %s %s %s/%s: %s", event.Type, event.Reason, strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, event.Message))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testAgentDeployment(status appsv1.DeploymentStatus) *appsv1.Deployment {
+	var replicas int32 = 1
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       agentDeploymentName,
+			Namespace:  defaultAgentNamespace,
+			Generation: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: status,
+	}
+}
+
+var testRolledOutStatus = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+
+func TestDeploymentRolledOut(t *testing.T) {
+	if !deploymentRolledOut(testAgentDeployment(testRolledOutStatus)) {
+		t.Errorf("expected the rollout to be done")
+	}
+	for _, status := range []appsv1.DeploymentStatus{
+		{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 1},
+		{ObservedGeneration: 2, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 0},
+	} {
+		if deploymentRolledOut(testAgentDeployment(status)) {
+			t.Errorf("expected %+v to still be rolling out", status)
+		}
+	}
+}
+
+func TestWaitForAgentRollout(t *testing.T) {
+	ctx := context.Background()
+
+	if err := waitForAgentRollout(ctx, fake.NewSimpleClientset(testAgentDeployment(testRolledOutStatus)), defaultAgentNamespace, 5*time.Second); err != nil {
+		t.Errorf("expected the rollout to be done, got %s", err)
+	}
+
+	// the rollout finishes while waiting
+	clientSet := fake.NewSimpleClientset(testAgentDeployment(appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1}))
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = clientSet.AppsV1().Deployments(defaultAgentNamespace).UpdateStatus(ctx, testAgentDeployment(testRolledOutStatus), metav1.UpdateOptions{})
+	}()
+	if err := waitForAgentRollout(ctx, clientSet, defaultAgentNamespace, 5*time.Second); err != nil {
+		t.Errorf("expected the rollout to be done, got %s", err)
+	}
+
+	err := waitForAgentRollout(ctx, fake.NewSimpleClientset(testAgentDeployment(appsv1.DeploymentStatus{})), defaultAgentNamespace, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "timed out after 1s") {
+		t.Errorf("expected the rollout to time out, got %v", err)
+	}
+}
+
+func TestWaitForAgentRolloutFailures(t *testing.T) {
+	ctx := context.Background()
+
+	stuck := testAgentDeployment(appsv1.DeploymentStatus{
+		ObservedGeneration: 2,
+		Conditions: []appsv1.DeploymentCondition{
+			{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "prodvana-agent-abc" has timed out progressing.`,
+			},
+		},
+	})
+	err := waitForAgentRollout(ctx, fake.NewSimpleClientset(stuck), defaultAgentNamespace, 10*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "has timed out progressing") {
+		t.Errorf("expected the progress deadline to fail the rollout, got %v", err)
+	}
+
+	// a new pod that cannot pull its image fails the rollout without waiting for the timeout
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "prodvana-agent-abc-123",
+			Namespace:         defaultAgentNamespace,
+			Labels:            map[string]string{"app": agentDeploymentName},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(time.Minute)),
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "default",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: `Back-off pulling image "prodvana/agent:missing"`,
+						},
+					},
+				},
+			},
+		},
+	}
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prodvana-agent-abc-123.1",
+			Namespace: defaultAgentNamespace,
+		},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: pod.Name},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "Failed",
+		Message:        "Failed to pull image: not found",
+		LastTimestamp:  metav1.Now(),
+	}
+	clientSet := fake.NewSimpleClientset(testAgentDeployment(appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 1}), pod, event)
+	start := time.Now()
+	err = waitForAgentRollout(ctx, clientSet, defaultAgentNamespace, time.Minute)
+	if time.Since(start) > 30*time.Second {
+		t.Errorf("expected the rollout to fail before the timeout")
+	}
+	if err == nil {
+		t.Fatalf("expected the image pull failure to fail the rollout")
+	}
+	for _, expected := range []string{
+		"container default of pod prodvana-agent-abc-123 is in ImagePullBackOff",
+		"Pod prodvana-agent-abc-123: Pending",
+		"Deployment: 0 updated, 0 available, 1 total replicas",
+		"Warning Failed pod/prodvana-agent-abc-123: Failed to pull image: not found",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in the error, got %s", expected, err)
+		}
+	}
+}
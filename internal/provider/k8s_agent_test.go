@@ -0,0 +1,509 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8s_testing "k8s.io/client-go/testing"
+)
+
+// testApplyClientset returns a fake clientset whose apply patches create
+// missing objects like the API server does, the fake only patches existing ones.
+func testApplyClientset(objects ...runtime.Object) *fake.Clientset {
+	clientSet := fake.NewSimpleClientset(objects...)
+	clientSet.PrependReactor("patch", "*", func(action k8s_testing.Action) (bool, runtime.Object, error) {
+		patch := action.(k8s_testing.PatchAction)
+		if patch.GetPatchType() != k8s_types.ApplyPatchType {
+			return false, nil, nil
+		}
+		_, err := clientSet.Tracker().Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if !k8s_errors.IsNotFound(err) {
+			return false, nil, err
+		}
+		obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(patch.GetPatch(), nil, nil)
+		if err != nil {
+			return true, nil, err
+		}
+		// let the default reactor apply the patch to the created object
+		return false, nil, clientSet.Tracker().Create(patch.GetResource(), obj, patch.GetNamespace())
+	})
+	return clientSet
+}
+
+func testStringMap(values map[string]string) types.Map {
+	elements := map[string]attr.Value{}
+	for k, v := range values {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func testAgentModel() *agentModel {
+	return &agentModel{
+		Namespace:          types.StringNull(),
+		NodeSelector:       types.MapNull(types.StringType),
+		Affinity:           types.StringNull(),
+		PriorityClassName:  types.StringNull(),
+		PodSecurityContext: types.StringNull(),
+		SecurityContext:    types.StringNull(),
+		Labels:             types.MapNull(types.StringType),
+		Annotations:        types.MapNull(types.StringType),
+		ImagePullSecrets:   types.ListNull(types.StringType),
+	}
+}
+
+func TestAgentOptionsDefaults(t *testing.T) {
+	ctx := context.Background()
+	for _, agent := range []*agentModel{nil, testAgentModel()} {
+		opts, diags := agentOptionsFromModel(ctx, agent)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		objects := buildAgentObjects(opts)
+		if objects.namespace.Name != defaultAgentNamespace {
+			t.Errorf("expected namespace %s, got %s", defaultAgentNamespace, objects.namespace.Name)
+		}
+		podSpec := objects.deployment.Spec.Template.Spec
+		if podSpec.SecurityContext != nil || podSpec.Affinity != nil || len(podSpec.Tolerations) != 0 || len(podSpec.NodeSelector) != 0 {
+			t.Errorf("expected no scheduling or security settings, got %+v", podSpec)
+		}
+	}
+}
+
+func TestAgentOptionsApplied(t *testing.T) {
+	ctx := context.Background()
+	agent := testAgentModel()
+	agent.Namespace = types.StringValue("pvn-system")
+	agent.Resources = &agentResourcesModel{
+		Requests: testStringMap(map[string]string{"cpu": "100m", "memory": "128Mi"}),
+		Limits:   testStringMap(map[string]string{"memory": "512Mi"}),
+	}
+	agent.NodeSelector = testStringMap(map[string]string{"pool": "system"})
+	agent.Tolerations = []*agentToleration{
+		{
+			Key:               types.StringValue("CriticalAddonsOnly"),
+			Operator:          types.StringValue("Exists"),
+			Value:             types.StringNull(),
+			Effect:            types.StringValue("NoSchedule"),
+			TolerationSeconds: types.Int64Null(),
+		},
+	}
+	agent.Affinity = types.StringValue(`{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"kubernetes.io/os","operator":"In","values":["linux"]}]}]}}}`)
+	agent.PriorityClassName = types.StringValue("system-cluster-critical")
+	agent.PodSecurityContext = types.StringValue(`
+runAsNonRoot: true
+seccompProfile:
+  type: RuntimeDefault
+`)
+	agent.SecurityContext = types.StringValue(`{"allowPrivilegeEscalation":false,"capabilities":{"drop":["ALL"]}}`)
+	agent.Labels = testStringMap(map[string]string{"team": "platform", "app": "ignored"})
+	agent.Annotations = testStringMap(map[string]string{"example.com/owner": "platform"})
+	agent.ImagePullSecrets = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("regcred")})
+
+	opts, diags := agentOptionsFromModel(ctx, agent)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	opts.runtimeId = "runtime-id"
+	opts.env = map[string]string{"B": "2", "A": "1"}
+	objects := buildAgentObjects(opts)
+
+	if objects.namespace.Name != "pvn-system" || objects.serviceAccount.Namespace != "pvn-system" || objects.deployment.Namespace != "pvn-system" {
+		t.Errorf("expected every object in pvn-system")
+	}
+	if objects.clusterRoleBinding.Subjects[0].Namespace != "pvn-system" {
+		t.Errorf("expected the role binding subject in pvn-system, got %s", objects.clusterRoleBinding.Subjects[0].Namespace)
+	}
+
+	deployment := objects.deployment
+	if deployment.Annotations[agentRuntimeIdAnnotation] != "runtime-id" || deployment.Annotations["example.com/owner"] != "platform" {
+		t.Errorf("unexpected deployment annotations %v", deployment.Annotations)
+	}
+	podTemplate := deployment.Spec.Template
+	if podTemplate.Labels["app"] != agentDeploymentName || podTemplate.Labels["team"] != "platform" {
+		t.Errorf("unexpected pod labels %v", podTemplate.Labels)
+	}
+
+	podSpec := podTemplate.Spec
+	if !reflect.DeepEqual(podSpec.NodeSelector, map[string]string{"pool": "system"}) {
+		t.Errorf("unexpected node selector %v", podSpec.NodeSelector)
+	}
+	if len(podSpec.Tolerations) != 1 || podSpec.Tolerations[0].Operator != corev1.TolerationOpExists || podSpec.Tolerations[0].Effect != corev1.TaintEffectNoSchedule {
+		t.Errorf("unexpected tolerations %v", podSpec.Tolerations)
+	}
+	if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key != "kubernetes.io/os" {
+		t.Errorf("unexpected affinity %v", podSpec.Affinity)
+	}
+	if podSpec.PriorityClassName != "system-cluster-critical" {
+		t.Errorf("unexpected priority class %s", podSpec.PriorityClassName)
+	}
+	if podSpec.SecurityContext == nil || !*podSpec.SecurityContext.RunAsNonRoot || podSpec.SecurityContext.SeccompProfile.Type != corev1.SeccompProfileTypeRuntimeDefault {
+		t.Errorf("unexpected pod security context %v", podSpec.SecurityContext)
+	}
+	if len(podSpec.ImagePullSecrets) != 1 || podSpec.ImagePullSecrets[0].Name != "regcred" {
+		t.Errorf("unexpected image pull secrets %v", podSpec.ImagePullSecrets)
+	}
+
+	container := podSpec.Containers[0]
+	if container.Resources.Requests.Cpu().String() != "100m" || container.Resources.Limits.Memory().String() != "512Mi" {
+		t.Errorf("unexpected resources %v", container.Resources)
+	}
+	if container.SecurityContext == nil || *container.SecurityContext.AllowPrivilegeEscalation || container.SecurityContext.Capabilities.Drop[0] != "ALL" {
+		t.Errorf("unexpected container security context %v", container.SecurityContext)
+	}
+	var envNames []string
+	for _, env := range container.Env {
+		envNames = append(envNames, env.Name)
+	}
+	if !reflect.DeepEqual(envNames, []string{"PVN_NAMESPACE", "PVN_RELEASE_CHANNEL", "A", "B"}) {
+		t.Errorf("expected sorted env, got %v", envNames)
+	}
+	if container.Env[0].Value != "pvn-system" {
+		t.Errorf("expected PVN_NAMESPACE=pvn-system, got %s", container.Env[0].Value)
+	}
+}
+
+func TestAgentOptionsInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	agent := testAgentModel()
+	agent.Resources = &agentResourcesModel{
+		Requests: testStringMap(map[string]string{"cpu": "a lot"}),
+		Limits:   types.MapNull(types.StringType),
+	}
+	if _, diags := agentOptionsFromModel(ctx, agent); !diags.HasError() {
+		t.Errorf("expected an invalid quantity to be reported")
+	}
+
+	agent = testAgentModel()
+	agent.SecurityContext = types.StringValue(`{"runAsNonRot": true}`)
+	if _, diags := agentOptionsFromModel(ctx, agent); !diags.HasError() {
+		t.Errorf("expected an unknown security context field to be reported")
+	}
+}
+
+func testAgentRbac(mode string, namespaces []string, clusterRole string) *agentRbacModel {
+	rbac := &agentRbacModel{
+		Mode:        types.StringValue(mode),
+		Namespaces:  types.ListNull(types.StringType),
+		ClusterRole: types.StringNull(),
+	}
+	if namespaces != nil {
+		var values []attr.Value
+		for _, ns := range namespaces {
+			values = append(values, types.StringValue(ns))
+		}
+		rbac.Namespaces = types.ListValueMust(types.StringType, values)
+	}
+	if clusterRole != "" {
+		rbac.ClusterRole = types.StringValue(clusterRole)
+	}
+	return rbac
+}
+
+func TestAgentRbacObjects(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		rbac                  *agentRbacModel
+		clusterRoleBinding    string
+		clusterRole           bool
+		roleBindingNamespaces []string
+	}{
+		{
+			rbac:               nil,
+			clusterRoleBinding: "cluster-admin",
+		},
+		{
+			rbac:               testAgentRbac(agentRbacModeScoped, nil, ""),
+			clusterRoleBinding: agentRoleName,
+			clusterRole:        true,
+		},
+		{
+			rbac:                  testAgentRbac(agentRbacModeNamespaced, []string{"apps", "prodvana", "jobs"}, ""),
+			roleBindingNamespaces: []string{"prodvana", "apps", "jobs"},
+		},
+		{
+			rbac:               testAgentRbac(agentRbacModeExisting, nil, "my-agent-role"),
+			clusterRoleBinding: "my-agent-role",
+		},
+	} {
+		agent := testAgentModel()
+		agent.Rbac = tc.rbac
+		opts, diags := agentOptionsFromModel(ctx, agent)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		objects := buildAgentObjects(opts)
+
+		if tc.clusterRoleBinding == "" {
+			if objects.clusterRoleBinding != nil {
+				t.Errorf("%s: expected no cluster role binding", opts.rbacMode)
+			}
+		} else if objects.clusterRoleBinding == nil || objects.clusterRoleBinding.RoleRef.Name != tc.clusterRoleBinding {
+			t.Errorf("%s: expected a cluster role binding to %s, got %v", opts.rbacMode, tc.clusterRoleBinding, objects.clusterRoleBinding)
+		}
+		if (objects.clusterRole != nil) != tc.clusterRole {
+			t.Errorf("%s: unexpected cluster role %v", opts.rbacMode, objects.clusterRole)
+		}
+		var namespaces []string
+		for i, roleBinding := range objects.roleBindings {
+			namespaces = append(namespaces, roleBinding.Namespace)
+			if objects.roles[i].Namespace != roleBinding.Namespace || roleBinding.Subjects[0].Namespace != defaultAgentNamespace {
+				t.Errorf("%s: unexpected role binding %v", opts.rbacMode, roleBinding)
+			}
+		}
+		if !reflect.DeepEqual(namespaces, tc.roleBindingNamespaces) {
+			t.Errorf("%s: expected role bindings in %v, got %v", opts.rbacMode, tc.roleBindingNamespaces, namespaces)
+		}
+	}
+}
+
+func TestAgentRbacInvalid(t *testing.T) {
+	ctx := context.Background()
+	for _, rbac := range []*agentRbacModel{
+		testAgentRbac(agentRbacModeNamespaced, nil, ""),
+		testAgentRbac(agentRbacModeNamespaced, []string{}, ""),
+		testAgentRbac(agentRbacModeExisting, nil, ""),
+		testAgentRbac(agentRbacModeScoped, []string{"apps"}, ""),
+		testAgentRbac(agentRbacModeClusterAdmin, nil, "my-agent-role"),
+	} {
+		agent := testAgentModel()
+		agent.Rbac = rbac
+		if _, diags := agentOptionsFromModel(ctx, agent); !diags.HasError() {
+			t.Errorf("expected %s with namespaces %s and cluster_role %s to be rejected", rbac.Mode, rbac.Namespaces, rbac.ClusterRole)
+		}
+	}
+}
+
+func TestAgentKubernetesObjectsLifecycle(t *testing.T) {
+	ctx := context.Background()
+	agent := testAgentModel()
+	agent.Rbac = testAgentRbac(agentRbacModeNamespaced, []string{"apps"}, "")
+	opts, diags := agentOptionsFromModel(ctx, agent)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	objects := buildAgentObjects(opts)
+
+	clientSet := testApplyClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}})
+	if err := applyKubernetesObjects(ctx, objects, clientSet); err != nil {
+		t.Fatalf("failed to apply agent objects: %s", err)
+	}
+	// applying again must be a no-op
+	if err := applyKubernetesObjects(ctx, objects, clientSet); err != nil {
+		t.Fatalf("failed to re-apply agent objects: %s", err)
+	}
+	if _, err := clientSet.RbacV1().RoleBindings("apps").Get(ctx, agentRoleName, metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected a role binding in apps: %s", err)
+	}
+	if _, err := clientSet.AppsV1().Deployments(defaultAgentNamespace).Get(ctx, agentDeploymentName, metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected the agent deployment: %s", err)
+	}
+
+	if err := deleteKubernetesObjects(ctx, objects, clientSet, 5*time.Second); err != nil {
+		t.Fatalf("failed to delete agent objects: %s", err)
+	}
+	if _, err := clientSet.RbacV1().RoleBindings("apps").Get(ctx, agentRoleName, metav1.GetOptions{}); !k8s_errors.IsNotFound(err) {
+		t.Errorf("expected the role binding in apps to be deleted, got %v", err)
+	}
+	if _, err := clientSet.RbacV1().Roles("apps").Get(ctx, agentRoleName, metav1.GetOptions{}); !k8s_errors.IsNotFound(err) {
+		t.Errorf("expected the role in apps to be deleted, got %v", err)
+	}
+	if _, err := clientSet.CoreV1().Namespaces().Get(ctx, "apps", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the apps namespace to be kept: %s", err)
+	}
+	if _, err := clientSet.CoreV1().Namespaces().Get(ctx, defaultAgentNamespace, metav1.GetOptions{}); !k8s_errors.IsNotFound(err) {
+		t.Errorf("expected the agent namespace to be deleted, got %v", err)
+	}
+}
+
+func TestAgentExistingClusterRoleIsKept(t *testing.T) {
+	ctx := context.Background()
+	agent := testAgentModel()
+	agent.Rbac = testAgentRbac(agentRbacModeExisting, nil, agentRoleName)
+	opts, diags := agentOptionsFromModel(ctx, agent)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	objects := buildAgentObjects(opts)
+
+	clientSet := testApplyClientset()
+	if _, err := clientSet.RbacV1().ClusterRoles().Create(ctx, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: agentRoleName}}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyKubernetesObjects(ctx, objects, clientSet); err != nil {
+		t.Fatalf("failed to apply agent objects: %s", err)
+	}
+	if err := deleteKubernetesObjects(ctx, objects, clientSet, 5*time.Second); err != nil {
+		t.Fatalf("failed to delete agent objects: %s", err)
+	}
+	if _, err := clientSet.RbacV1().ClusterRoleBindings().Get(ctx, clusterRoleBindingName, metav1.GetOptions{}); !k8s_errors.IsNotFound(err) {
+		t.Errorf("expected the cluster role binding to be deleted, got %v", err)
+	}
+	if _, err := clientSet.RbacV1().ClusterRoles().Get(ctx, agentRoleName, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the existing cluster role to be kept: %s", err)
+	}
+}
+
+func TestAgentKubernetesObjectsUpdatedInPlace(t *testing.T) {
+	ctx := context.Background()
+	opts, diags := agentOptionsFromModel(ctx, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	opts.env = map[string]string{"PROXY": "http://proxy:8080"}
+	clientSet := testApplyClientset()
+	if err := applyKubernetesObjects(ctx, buildAgentObjects(opts), clientSet); err != nil {
+		t.Fatalf("failed to apply agent objects: %s", err)
+	}
+
+	opts.env = map[string]string{"PROXY": "http://other-proxy:8080"}
+	if err := applyKubernetesObjects(ctx, buildAgentObjects(opts), clientSet); err != nil {
+		t.Fatalf("failed to apply agent objects: %s", err)
+	}
+	for _, action := range clientSet.Actions() {
+		if action.GetVerb() == "delete" {
+			t.Errorf("expected no deletes, got %v", action)
+		}
+		if action.GetVerb() == "patch" && action.(k8s_testing.PatchAction).GetPatchType() != k8s_types.ApplyPatchType {
+			t.Errorf("expected server-side apply, got %v", action)
+		}
+	}
+	if options := applyPatchOptions(); options.FieldManager != agentFieldManager || options.Force == nil || !*options.Force {
+		t.Errorf("expected a forced apply by %s, got %v", agentFieldManager, options)
+	}
+	deployment, err := clientSet.AppsV1().Deployments(defaultAgentNamespace).Get(ctx, agentDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := deployment.Spec.Template.Spec.Containers[0].Env
+	if env[len(env)-1].Value != "http://other-proxy:8080" {
+		t.Errorf("expected the updated env, got %v", env)
+	}
+}
+
+func TestStaleAgentObjects(t *testing.T) {
+	ctx := context.Background()
+	build := func(namespace string, rbac *agentRbacModel) *agentObjects {
+		agent := testAgentModel()
+		agent.Namespace = types.StringValue(namespace)
+		agent.Rbac = rbac
+		opts, diags := agentOptionsFromModel(ctx, agent)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		return buildAgentObjects(opts)
+	}
+
+	stale := staleAgentObjects(build("prodvana", nil), build("prodvana", nil))
+	if !reflect.DeepEqual(stale, &agentObjects{}) {
+		t.Errorf("expected nothing stale, got %+v", stale)
+	}
+
+	stale = staleAgentObjects(build("prodvana", nil), build("pvn-system", nil))
+	if stale.namespace == nil || stale.namespace.Name != "prodvana" || stale.deployment == nil || stale.serviceAccount == nil {
+		t.Errorf("expected the old namespace objects to be stale, got %+v", stale)
+	}
+	if stale.clusterRoleBinding != nil {
+		t.Errorf("expected the cluster role binding to be applied in place, got %+v", stale.clusterRoleBinding)
+	}
+
+	stale = staleAgentObjects(build("prodvana", testAgentRbac(agentRbacModeScoped, nil, "")), build("prodvana", testAgentRbac(agentRbacModeNamespaced, []string{"apps"}, "")))
+	if stale.clusterRole == nil || stale.clusterRoleBinding == nil || stale.namespace != nil {
+		t.Errorf("expected the cluster role and binding to be stale, got %+v", stale)
+	}
+
+	stale = staleAgentObjects(build("prodvana", nil), build("prodvana", testAgentRbac(agentRbacModeScoped, nil, "")))
+	if stale.clusterRoleBinding == nil || stale.clusterRoleBinding.RoleRef.Name != "cluster-admin" {
+		t.Errorf("expected the cluster-admin binding to be stale, got %+v", stale.clusterRoleBinding)
+	}
+
+	stale = staleAgentObjects(build("prodvana", testAgentRbac(agentRbacModeNamespaced, []string{"apps", "jobs"}, "")), build("prodvana", testAgentRbac(agentRbacModeNamespaced, []string{"apps"}, "")))
+	if len(stale.roles) != 1 || stale.roles[0].Namespace != "jobs" || stale.roleBindings[0].Namespace != "jobs" {
+		t.Errorf("expected only the jobs role to be stale, got %+v", stale.roles)
+	}
+}
+
+func TestAgentDriftDetection(t *testing.T) {
+	ctx := context.Background()
+	opts, diags := agentOptionsFromModel(ctx, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	opts.image = "prodvana/agent:1"
+	objects := buildAgentObjects(opts)
+	clientSet := testApplyClientset()
+	if err := applyKubernetesObjects(ctx, objects, clientSet); err != nil {
+		t.Fatalf("failed to apply agent objects: %s", err)
+	}
+	applied, err := agentObjectHashes(ctx, clientSet, objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 4 {
+		t.Errorf("expected hashes for the namespace, service account, binding and deployment, got %v", applied)
+	}
+
+	deployments := clientSet.AppsV1().Deployments(defaultAgentNamespace)
+	deployment, err := deployments.Get(ctx, agentDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// changes made by controllers or other tools to fields the provider does not set are not drift
+	deployment.Annotations["deployment.kubernetes.io/revision"] = "2"
+	deployment.Spec.Template.Annotations = map[string]string{"kubectl.kubernetes.io/restartedAt": "now"}
+	deployment.Status.AvailableReplicas = 1
+	if deployment, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	live, err := agentObjectHashes(ctx, clientSet, objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift := agentDrift(applied, live); len(drift) != 0 {
+		t.Errorf("expected no drift, got %v", drift)
+	}
+
+	deployment.Spec.Template.Spec.Containers[0].Image = "prodvana/agent:2"
+	if _, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := clientSet.RbacV1().ClusterRoleBindings().Delete(ctx, clusterRoleBindingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	live, err = agentObjectHashes(ctx, clientSet, objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDrift := []string{
+		agentObjectKey("ClusterRoleBinding", "", clusterRoleBindingName),
+		agentObjectKey("Deployment", defaultAgentNamespace, agentDeploymentName),
+	}
+	if drift := agentDrift(applied, live); !reflect.DeepEqual(drift, expectedDrift) {
+		t.Errorf("expected drift %v, got %v", expectedDrift, drift)
+	}
+
+	// applying again reconciles the objects
+	if err := applyKubernetesObjects(ctx, objects, clientSet); err != nil {
+		t.Fatalf("failed to apply agent objects: %s", err)
+	}
+	live, err = agentObjectHashes(ctx, clientSet, objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if drift := agentDrift(applied, live); len(drift) != 0 {
+		t.Errorf("expected no drift after applying, got %v", drift)
+	}
+}
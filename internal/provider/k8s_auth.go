@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// exec credential plugin API versions client-go still understands
+var k8sExecApiVersions = []string{
+	"client.authentication.k8s.io/v1",
+	"client.authentication.k8s.io/v1beta1",
+}
+
+// k8sAuthAttribute is a Kubernetes connection attribute as it appears in the configuration.
+type k8sAuthAttribute struct {
+	name  string
+	value attr.Value
+}
+
+func (a k8sAuthAttribute) set() bool {
+	return a.value != nil && !a.value.IsNull()
+}
+
+func setK8sAuthAttributes(attributes ...k8sAuthAttribute) []string {
+	var names []string
+	for _, a := range attributes {
+		if a.set() {
+			names = append(names, "`"+a.name+"`")
+		}
+	}
+	return names
+}
+
+// validateK8sAuthConfig reports Kubernetes connection attributes that cannot be
+// used together, or that are ignored without another attribute. Only the
+// configuration is checked, KUBE_* environment variables fill in attributes
+// that are not configured and are never reported as conflicts.
+func validateK8sAuthConfig(data *ManagedK8sRuntimeResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var exec attr.Value
+	if data.Exec != nil {
+		// command is required, it is set whenever the exec block is
+		exec = data.Exec.Command
+	}
+	attributes := map[string]k8sAuthAttribute{}
+	for _, a := range []k8sAuthAttribute{
+		{"host", data.Host},
+		{"username", data.Username},
+		{"password", data.Password},
+		{"client_certificate", data.ClientCertificate},
+		{"client_key", data.ClientKey},
+		{"cluster_ca_certificate", data.ClusterCaCertificate},
+		{"config_paths", data.ConfigPaths},
+		{"config_path", data.ConfigPath},
+		{"config_context", data.ConfigContext},
+		{"config_context_auth_info", data.ConfigContextAuthInfo},
+		{"config_context_cluster", data.ConfigContextCluster},
+		{"token", data.Token},
+		{"exec", exec},
+	} {
+		attributes[a.name] = a
+	}
+
+	conflict := func(name string, others ...string) {
+		if !attributes[name].set() {
+			return
+		}
+		var otherAttributes []k8sAuthAttribute
+		for _, other := range others {
+			otherAttributes = append(otherAttributes, attributes[other])
+		}
+		if conflicting := setK8sAuthAttributes(otherAttributes...); len(conflicting) > 0 {
+			diags.AddAttributeError(path.Root(name), "Conflicting Kubernetes Configuration", fmt.Sprintf("`%s` cannot be set together with %s", name, strings.Join(conflicting, ", ")))
+		}
+	}
+	requires := func(name, other string) {
+		if attributes[name].set() && !attributes[other].set() {
+			diags.AddAttributeError(path.Root(name), "Incomplete Kubernetes Configuration", fmt.Sprintf("`%s` requires `%s` to be set", name, other))
+		}
+	}
+
+	// the cluster is either read from kube config files or configured statically
+	conflict("config_path", "config_paths", "host")
+	conflict("config_paths", "host")
+	if !attributes["config_path"].set() && !attributes["config_paths"].set() && os.Getenv("KUBE_CONFIG_PATH") == "" && os.Getenv("KUBE_CONFIG_PATHS") == "" {
+		for _, name := range []string{"config_context", "config_context_auth_info", "config_context_cluster"} {
+			if attributes[name].set() {
+				diags.AddAttributeError(path.Root(name), "Incomplete Kubernetes Configuration", fmt.Sprintf("`%s` selects from a kube config file and requires `config_path` or `config_paths` (or `KUBE_CONFIG_PATH` or `KUBE_CONFIG_PATHS`) to be set", name))
+			}
+		}
+	}
+
+	// only one way to authenticate
+	conflict("token", "exec", "username", "client_certificate")
+	conflict("exec", "username", "client_certificate")
+	conflict("username", "client_certificate")
+	requires("username", "password")
+	requires("password", "username")
+	requires("client_certificate", "client_key")
+	requires("client_key", "client_certificate")
+
+	if !data.Insecure.IsNull() && !data.Insecure.IsUnknown() && data.Insecure.ValueBool() && attributes["cluster_ca_certificate"].set() {
+		diags.AddAttributeError(path.Root("insecure"), "Conflicting Kubernetes Configuration", "`insecure` cannot be enabled together with `cluster_ca_certificate`, the certificate would not be verified")
+	}
+	return diags
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com:6443
+- name: prod
+  cluster:
+    server: https://prod.example.com:6443
+users:
+- name: dev-user
+  user:
+    token: dev-token
+- name: prod-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: prod-credentials
+      args: ["--cluster", "prod"]
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: dev-user
+- name: prod
+  context:
+    cluster: prod
+    user: prod-user
+`
+
+func writeTestKubeconfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testK8sExec(command string) *execModel {
+	return &execModel{
+		ApiVersion: types.StringValue("client.authentication.k8s.io/v1"),
+		Command:    types.StringValue(command),
+		Env:        types.MapNull(types.StringType),
+		Args:       types.ListNull(types.StringType),
+	}
+}
+
+func TestValidateK8sAuthConfig(t *testing.T) {
+	t.Setenv("KUBE_CONFIG_PATH", "")
+	t.Setenv("KUBE_CONFIG_PATHS", "")
+
+	for _, tc := range []struct {
+		name     string
+		data     ManagedK8sRuntimeResourceModel
+		expected []string
+	}{
+		{
+			name: "nothing configured",
+		},
+		{
+			name: "kube config with context",
+			data: ManagedK8sRuntimeResourceModel{
+				ConfigPath:    types.StringValue("~/.kube/config"),
+				ConfigContext: types.StringValue("dev"),
+			},
+		},
+		{
+			name: "host with token",
+			data: ManagedK8sRuntimeResourceModel{
+				Host:                 types.StringValue("https://dev.example.com"),
+				ClusterCaCertificate: types.StringValue("ca"),
+				Token:                types.StringUnknown(),
+			},
+		},
+		{
+			name: "host with client certificate",
+			data: ManagedK8sRuntimeResourceModel{
+				Host:              types.StringValue("https://dev.example.com"),
+				ClientCertificate: types.StringValue("cert"),
+				ClientKey:         types.StringValue("key"),
+			},
+		},
+		{
+			name: "host with exec",
+			data: ManagedK8sRuntimeResourceModel{
+				Host: types.StringValue("https://dev.example.com"),
+				Exec: testK8sExec("aws"),
+			},
+		},
+		{
+			name: "config_path with config_paths and host",
+			data: ManagedK8sRuntimeResourceModel{
+				ConfigPath:  types.StringValue("~/.kube/config"),
+				ConfigPaths: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("~/.kube/other")}),
+				Host:        types.StringValue("https://dev.example.com"),
+			},
+			expected: []string{"`config_path` cannot be set together with `config_paths`, `host`", "`config_paths` cannot be set together with `host`"},
+		},
+		{
+			name: "token with exec",
+			data: ManagedK8sRuntimeResourceModel{
+				Host:  types.StringValue("https://dev.example.com"),
+				Token: types.StringValue("token"),
+				Exec:  testK8sExec("aws"),
+			},
+			expected: []string{"`token` cannot be set together with `exec`"},
+		},
+		{
+			name: "basic auth with client certificate",
+			data: ManagedK8sRuntimeResourceModel{
+				Host:              types.StringValue("https://dev.example.com"),
+				Username:          types.StringValue("admin"),
+				ClientCertificate: types.StringValue("cert"),
+			},
+			expected: []string{"`username` cannot be set together with `client_certificate`", "`username` requires `password`", "`client_certificate` requires `client_key`"},
+		},
+		{
+			name: "context without kube config",
+			data: ManagedK8sRuntimeResourceModel{
+				Host:                 types.StringValue("https://dev.example.com"),
+				ConfigContextCluster: types.StringValue("dev"),
+			},
+			expected: []string{"`config_context_cluster` selects from a kube config file"},
+		},
+		{
+			name: "insecure with ca certificate",
+			data: ManagedK8sRuntimeResourceModel{
+				Host:                 types.StringValue("https://dev.example.com"),
+				Insecure:             types.BoolValue(true),
+				ClusterCaCertificate: types.StringValue("ca"),
+			},
+			expected: []string{"`insecure` cannot be enabled together with `cluster_ca_certificate`"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateK8sAuthConfig(&tc.data)
+			if len(diags) != len(tc.expected) {
+				t.Fatalf("expected %d errors, got %v", len(tc.expected), diags)
+			}
+			for i, expected := range tc.expected {
+				if !strings.Contains(diags[i].Detail(), expected) {
+					t.Errorf("expected error %d to contain %q, got %q", i, expected, diags[i].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestValidateK8sAuthConfigContextFromEnv(t *testing.T) {
+	t.Setenv("KUBE_CONFIG_PATH", "~/.kube/config")
+	data := ManagedK8sRuntimeResourceModel{
+		ConfigContext: types.StringValue("dev"),
+	}
+	if diags := validateK8sAuthConfig(&data); diags.HasError() {
+		t.Errorf("expected KUBE_CONFIG_PATH to provide the kube config, got %v", diags)
+	}
+}
+
+func TestInitializeConfigurationKubeconfig(t *testing.T) {
+	ctx := context.Background()
+	kubeconfig := writeTestKubeconfig(t, testKubeconfig)
+	r := &ManagedK8sRuntimeResource{}
+
+	// current context
+	cfg, err := r.initializeConfiguration(ctx, diag.Diagnostics{}, &ManagedK8sRuntimeResourceModel{
+		ConfigPath: types.StringValue(kubeconfig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://dev.example.com:6443" || cfg.BearerToken != "dev-token" {
+		t.Errorf("expected the dev context, got host %s and token %s", cfg.Host, cfg.BearerToken)
+	}
+
+	// context with an exec plugin
+	cfg, err = r.initializeConfiguration(ctx, diag.Diagnostics{}, &ManagedK8sRuntimeResourceModel{
+		ConfigPath:    types.StringValue(kubeconfig),
+		ConfigContext: types.StringValue("prod"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://prod.example.com:6443" {
+		t.Errorf("expected the prod cluster, got %s", cfg.Host)
+	}
+	if cfg.ExecProvider == nil || cfg.ExecProvider.Command != "prod-credentials" || strings.Join(cfg.ExecProvider.Args, " ") != "--cluster prod" {
+		t.Errorf("expected the prod exec plugin, got %+v", cfg.ExecProvider)
+	}
+
+	// cluster and user picked separately
+	cfg, err = r.initializeConfiguration(ctx, diag.Diagnostics{}, &ManagedK8sRuntimeResourceModel{
+		ConfigPaths:           types.ListValueMust(types.StringType, []attr.Value{types.StringValue(kubeconfig)}),
+		ConfigContextCluster:  types.StringValue("prod"),
+		ConfigContextAuthInfo: types.StringValue("dev-user"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://prod.example.com:6443" || cfg.BearerToken != "dev-token" {
+		t.Errorf("expected the prod cluster with the dev user, got host %s and token %s", cfg.Host, cfg.BearerToken)
+	}
+
+	if _, err := r.initializeConfiguration(ctx, diag.Diagnostics{}, &ManagedK8sRuntimeResourceModel{
+		ConfigPath:    types.StringValue(kubeconfig),
+		ConfigContext: types.StringValue("staging"),
+	}); err == nil {
+		t.Errorf("expected a missing context to fail")
+	}
+}
+
+func TestInitializeConfigurationStatic(t *testing.T) {
+	ctx := context.Background()
+	r := &ManagedK8sRuntimeResource{}
+
+	exec := testK8sExec("aws")
+	exec.Args = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("eks"), types.StringValue("get-token")})
+	exec.Env = types.MapValueMust(types.StringType, map[string]attr.Value{"AWS_PROFILE": types.StringValue("prod")})
+	cfg, err := r.initializeConfiguration(ctx, diag.Diagnostics{}, &ManagedK8sRuntimeResourceModel{
+		Host:          types.StringValue("prod.example.com"),
+		Insecure:      types.BoolValue(true),
+		TlsServerName: types.StringValue("kubernetes"),
+		Exec:          exec,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://prod.example.com" {
+		t.Errorf("expected the host to default to https, got %s", cfg.Host)
+	}
+	if !cfg.Insecure || cfg.ServerName != "kubernetes" {
+		t.Errorf("expected insecure access with server name kubernetes, got %v and %s", cfg.Insecure, cfg.ServerName)
+	}
+	if cfg.ExecProvider == nil || cfg.ExecProvider.Command != "aws" || strings.Join(cfg.ExecProvider.Args, " ") != "eks get-token" {
+		t.Fatalf("expected the aws exec plugin, got %+v", cfg.ExecProvider)
+	}
+	if len(cfg.ExecProvider.Env) != 1 || cfg.ExecProvider.Env[0].Name != "AWS_PROFILE" || cfg.ExecProvider.Env[0].Value != "prod" {
+		t.Errorf("expected AWS_PROFILE to be passed to the exec plugin, got %+v", cfg.ExecProvider.Env)
+	}
+}
@@ -12,7 +12,6 @@ import (
 	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
-	"google.golang.org/grpc"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -75,18 +74,18 @@ func (d *K8sRuntimeDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = env_pb.NewEnvironmentManagerClient(conn)
+	d.client = clients.Environment
 }
 func (d *K8sRuntimeDataSource) read(ctx context.Context, diags diag.Diagnostics, data *K8sRuntimeDataSourceModel) error {
 	resp, err := d.client.GetCluster(ctx, &env_pb.GetClusterReq{
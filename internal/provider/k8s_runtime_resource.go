@@ -9,7 +9,6 @@ import (
 	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -41,12 +40,14 @@ type K8sRuntimeResourceModel struct {
 	Name types.String `tfsdk:"name"`
 	Id   types.String `tfsdk:"id"`
 
-	Labels types.List `tfsdk:"labels"`
+	Labels        types.List `tfsdk:"labels"`
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
 
-	AgentApiToken types.String `tfsdk:"agent_api_token"`
-	AgentURL      types.String `tfsdk:"agent_url"`
-	AgentImage    types.String `tfsdk:"agent_image"`
-	AgentArgs     types.List   `tfsdk:"agent_args"`
+	AgentApiToken  types.String `tfsdk:"agent_api_token"`
+	AgentURL       types.String `tfsdk:"agent_url"`
+	AgentImage     types.String `tfsdk:"agent_image"`
+	AgentArgs      types.List   `tfsdk:"agent_args"`
+	AgentManifests types.String `tfsdk:"agent_manifests"`
 }
 
 func (r *K8sRuntimeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -79,6 +80,7 @@ func (r *K8sRuntimeResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Optional:            true,
 				NestedObject:        labels.LabelDefinitionNestedObjectResourceSchema(),
 			},
+			"adopt_existing": adoptExistingSchema("runtime"),
 			"agent_api_token": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "API Token used for linking the Kubernetes Prodvana agent",
@@ -107,6 +109,11 @@ func (r *K8sRuntimeResource) Schema(ctx context.Context, req resource.SchemaRequ
 				MarkdownDescription: "Arguments to pass to the Kubernetes Prodvana agent container.",
 				ElementType:         types.StringType,
 			},
+			"agent_manifests": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Multi-document YAML manifest installing the Kubernetes Prodvana agent in the `prodvana` namespace, rendered like `prodvana_managed_k8s_runtime` does with `install_mode = \"manifests\"`.",
+			},
 		},
 	}
 }
@@ -117,18 +124,18 @@ func (r *K8sRuntimeResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = env_pb.NewEnvironmentManagerClient(conn)
+	r.client = clients.Environment
 }
 
 func readK8sRuntimeData(ctx context.Context, diags diag.Diagnostics, client env_pb.EnvironmentManagerClient, data *K8sRuntimeResourceModel) error {
@@ -156,6 +163,11 @@ func readK8sRuntimeData(ctx context.Context, diags diag.Diagnostics, client env_
 		return errors.Errorf("Failed to convert agent args: %v", valDiags.Errors())
 	}
 	data.AgentArgs = args
+	manifests, err := defaultAgentManifests(linkResp.ClusterId, linkResp.K8SAgentImage, linkResp.K8SAgentArgs)
+	if err != nil {
+		return errors.Wrap(err, "Failed to render agent manifests")
+	}
+	data.AgentManifests = types.StringValue(manifests)
 
 	getResp, err := client.GetCluster(ctx, &env_pb.GetClusterReq{
 		Runtime:     data.Name.ValueString(),
@@ -251,6 +263,16 @@ func (r *K8sRuntimeResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	if !data.AdoptExisting.ValueBool() {
+		_, err := r.client.GetCluster(ctx, &env_pb.GetClusterReq{
+			Runtime: data.Name.ValueString(),
+		})
+		if err := checkNotExists("runtime", data.Name.ValueString(), data.Name.ValueString(), err); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create runtime, got error: %s", err))
+			return
+		}
+	}
+
 	err := r.createOrUpdate(ctx, resp.Diagnostics, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create runtime, got error: %s", err))
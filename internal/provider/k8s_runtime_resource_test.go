@@ -21,6 +21,7 @@ func TestAccK8sRuntimeResource(t *testing.T) {
 					resource.TestCheckResourceAttr("prodvana_k8s_runtime.test", "name", runtimeName),
 					resource.TestCheckResourceAttrSet("prodvana_k8s_runtime.test", "id"),
 					resource.TestCheckResourceAttrSet("prodvana_k8s_runtime.test", "agent_api_token"),
+					resource.TestCheckResourceAttrSet("prodvana_k8s_runtime.test", "agent_manifests"),
 				),
 			},
 			// ImportState testing
@@ -43,6 +44,7 @@ func TestAccK8sRuntimeResource(t *testing.T) {
 		},
 	})
 }
+
 func TestAccK8sRuntimeResourceLabels(t *testing.T) {
 	runtimeName := uniqueTestName("runtime-tests")
 	resource.Test(t, resource.TestCase{
@@ -2,7 +2,9 @@ package labels
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"strings"
 
 	labels_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/labels"
 
@@ -152,3 +154,107 @@ func labelValueValidator() validator.String {
 		"must contain only alphanumeric characters, @, -, _, \\, and start with a letter.",
 	)
 }
+
+// Matches reports whether have contains every label in want, with the same value.
+func Matches(have []*labels_pb.LabelDefinition, want []LabelDefinition) bool {
+	values := make(map[string]string, len(have))
+	for _, label := range have {
+		values[label.Label] = label.Value
+	}
+	for _, label := range want {
+		if value, ok := values[label.Label]; !ok || value != label.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Selector operators, see Requirement.
+const (
+	OperatorEquals = "equals"
+	OperatorIn     = "in"
+	OperatorExists = "exists"
+)
+
+var Operators = []string{OperatorEquals, OperatorIn, OperatorExists}
+
+// Requirement is a single label selector expression, e.g. env equals prod.
+type Requirement struct {
+	Label    string   `tfsdk:"label"`
+	Operator string   `tfsdk:"operator"`
+	Values   []string `tfsdk:"values"`
+}
+
+// Validate checks that the number of values fits the operator.
+func (r Requirement) Validate() error {
+	switch r.Operator {
+	case OperatorEquals:
+		if len(r.Values) != 1 {
+			return fmt.Errorf("operator %s on label %s requires exactly one value, got %d", r.Operator, r.Label, len(r.Values))
+		}
+	case OperatorIn:
+		if len(r.Values) == 0 {
+			return fmt.Errorf("operator %s on label %s requires at least one value", r.Operator, r.Label)
+		}
+	case OperatorExists:
+		if len(r.Values) != 0 {
+			return fmt.Errorf("operator %s on label %s does not take values", r.Operator, r.Label)
+		}
+	default:
+		return fmt.Errorf("unknown operator %s on label %s", r.Operator, r.Label)
+	}
+	return nil
+}
+
+// Matches reports whether the labels satisfy the requirement.
+func (r Requirement) Matches(have []*labels_pb.LabelDefinition) bool {
+	for _, label := range have {
+		if label.Label != r.Label {
+			continue
+		}
+		switch r.Operator {
+		case OperatorExists:
+			return true
+		case OperatorEquals, OperatorIn:
+			for _, value := range r.Values {
+				if label.Value == value {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// MatchesAll reports whether the labels satisfy every requirement.
+func MatchesAll(have []*labels_pb.LabelDefinition, requirements []Requirement) bool {
+	for _, requirement := range requirements {
+		if !requirement.Matches(have) {
+			return false
+		}
+	}
+	return true
+}
+
+func RequirementNestedObjectDataSourceSchema() ds_schema.NestedAttributeObject {
+	return ds_schema.NestedAttributeObject{
+		Attributes: map[string]ds_schema.Attribute{
+			"label": ds_schema.StringAttribute{
+				MarkdownDescription: "Label name",
+				Required:            true,
+			},
+			"operator": ds_schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How to match the label, one of (%s). `equals` takes exactly one value, `in` takes one or more, `exists` takes none", strings.Join(Operators, ", ")),
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(Operators...),
+				},
+			},
+			"values": ds_schema.ListAttribute{
+				MarkdownDescription: "Label values to match",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
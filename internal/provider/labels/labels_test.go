@@ -1,6 +1,10 @@
 package labels
 
-import "testing"
+import (
+	"testing"
+
+	labels_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/labels"
+)
 
 func TestLabelValueRegex(t *testing.T) {
 	valid := []string{
@@ -60,3 +64,91 @@ func TestLabelValueRegex(t *testing.T) {
 		}
 	}
 }
+
+func TestMatches(t *testing.T) {
+	have := []*labels_pb.LabelDefinition{
+		{Label: "env", Value: "prod"},
+		{Label: "region", Value: "us-east-1"},
+	}
+
+	cases := []struct {
+		want    []LabelDefinition
+		matches bool
+	}{
+		{nil, true},
+		{[]LabelDefinition{{Label: "env", Value: "prod"}}, true},
+		{[]LabelDefinition{{Label: "env", Value: "prod"}, {Label: "region", Value: "us-east-1"}}, true},
+		{[]LabelDefinition{{Label: "env", Value: "staging"}}, false},
+		{[]LabelDefinition{{Label: "env", Value: "prod"}, {Label: "team", Value: "infra"}}, false},
+		{[]LabelDefinition{{Label: "region", Value: ""}}, false},
+	}
+
+	for _, c := range cases {
+		if got := Matches(have, c.want); got != c.matches {
+			t.Errorf("Matches(%v) = %v, expected %v", c.want, got, c.matches)
+		}
+	}
+}
+
+func TestRequirementMatches(t *testing.T) {
+	have := []*labels_pb.LabelDefinition{
+		{Label: "env", Value: "prod"},
+		{Label: "region", Value: "us-east-1"},
+	}
+
+	cases := []struct {
+		requirement Requirement
+		matches     bool
+	}{
+		{Requirement{Label: "env", Operator: OperatorEquals, Values: []string{"prod"}}, true},
+		{Requirement{Label: "env", Operator: OperatorEquals, Values: []string{"staging"}}, false},
+		{Requirement{Label: "region", Operator: OperatorIn, Values: []string{"us-west-2", "us-east-1"}}, true},
+		{Requirement{Label: "region", Operator: OperatorIn, Values: []string{"eu-west-1"}}, false},
+		{Requirement{Label: "env", Operator: OperatorExists}, true},
+		{Requirement{Label: "team", Operator: OperatorExists}, false},
+		{Requirement{Label: "team", Operator: OperatorIn, Values: []string{""}}, false},
+	}
+
+	for _, c := range cases {
+		if got := c.requirement.Matches(have); got != c.matches {
+			t.Errorf("%+v Matches = %v, expected %v", c.requirement, got, c.matches)
+		}
+	}
+
+	all := []Requirement{
+		{Label: "env", Operator: OperatorEquals, Values: []string{"prod"}},
+		{Label: "region", Operator: OperatorExists},
+	}
+	if !MatchesAll(have, all) {
+		t.Errorf("expected %+v to match all requirements", have)
+	}
+	if MatchesAll(have, append(all, Requirement{Label: "team", Operator: OperatorExists})) {
+		t.Errorf("expected %+v to not match a missing label", have)
+	}
+}
+
+func TestRequirementValidate(t *testing.T) {
+	valid := []Requirement{
+		{Label: "env", Operator: OperatorEquals, Values: []string{"prod"}},
+		{Label: "env", Operator: OperatorIn, Values: []string{"prod", "staging"}},
+		{Label: "env", Operator: OperatorExists},
+	}
+	invalid := []Requirement{
+		{Label: "env", Operator: OperatorEquals},
+		{Label: "env", Operator: OperatorEquals, Values: []string{"prod", "staging"}},
+		{Label: "env", Operator: OperatorIn},
+		{Label: "env", Operator: OperatorExists, Values: []string{"prod"}},
+		{Label: "env", Operator: "not_in", Values: []string{"prod"}},
+	}
+
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got %s", r, err)
+		}
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", r)
+		}
+	}
+}
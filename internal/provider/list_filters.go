@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	labels_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/labels"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+)
+
+// listFilter holds the filters shared by the plural data sources.
+type listFilter struct {
+	nameRegex *regexp.Regexp
+	labels    []labels.LabelDefinition
+}
+
+func newListFilter(nameRegex types.String, labelDefinitions []labels.LabelDefinition) (*listFilter, error) {
+	filter := &listFilter{
+		labels: labelDefinitions,
+	}
+	if !nameRegex.IsNull() {
+		re, err := regexp.Compile(nameRegex.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		filter.nameRegex = re
+	}
+	return filter, nil
+}
+
+func (f *listFilter) matchesName(name string) bool {
+	return f.nameRegex == nil || f.nameRegex.MatchString(name)
+}
+
+func (f *listFilter) hasLabels() bool {
+	return len(f.labels) > 0
+}
+
+func (f *listFilter) matchesLabels(have []*labels_pb.LabelDefinition) bool {
+	return labels.Matches(have, f.labels)
+}
+
+func listFilterDataSourceAttributes(objects string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Data source identifier",
+			Computed:            true,
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: "Only return " + objects + " whose name matches this regular expression",
+			Optional:            true,
+			Validators: []validator.String{
+				validators.ValidRegex(),
+			},
+		},
+		"labels": schema.ListNestedAttribute{
+			MarkdownDescription: "Only return " + objects + " that have all of these labels",
+			Optional:            true,
+			NestedObject:        labels.LabelDefinitionNestedObjectDataSourceSchema(),
+		},
+		"names": schema.ListAttribute{
+			MarkdownDescription: "Names of the matching " + objects + ", sorted",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package provider
+
+import "sync"
+
+// keyedMutex serializes read-modify-write cycles on the same Prodvana object
+// across resources, Terraform applies independent resources in parallel.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: map[string]*sync.Mutex{}}
+}
+
+// Lock locks key and returns the function that unlocks it.
+func (m *keyedMutex) Lock(key string) func() {
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
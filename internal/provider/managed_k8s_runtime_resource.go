@@ -2,7 +2,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
@@ -11,33 +15,41 @@ import (
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/defaults"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
+	k8s_types "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
+	// apply the agent objects to the cluster
+	agentInstallModeApply = "apply"
+	// only render the agent objects, they are applied outside Terraform
+	agentInstallModeManifests = "manifests"
+
+	agentFieldManager        = "terraform-provider-prodvana"
 	defaultAgentNamespace    = "prodvana"
 	clusterRoleBindingName   = "prodvana-access"
 	serviceAccountName       = "prodvana"
@@ -47,6 +59,8 @@ const (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ManagedK8sRuntimeResource{}
+var _ resource.ResourceWithValidateConfig = &ManagedK8sRuntimeResource{}
+var _ resource.ResourceWithModifyPlan = &ManagedK8sRuntimeResource{}
 
 func NewManagedK8sRuntimeResource() resource.Resource {
 	return &ManagedK8sRuntimeResource{}
@@ -55,7 +69,7 @@ func NewManagedK8sRuntimeResource() resource.Resource {
 // ManagedK8sRuntimeResource defines the resource implementation.
 type ManagedK8sRuntimeResource struct {
 	client    env_pb.EnvironmentManagerClient
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // ManagedK8sRuntimeResourceModel describes the resource data model.
@@ -65,7 +79,8 @@ type ManagedK8sRuntimeResourceModel struct {
 
 	AgentEnv types.Map `tfsdk:"agent_env"`
 
-	Labels types.List `tfsdk:"labels"`
+	Labels        types.List `tfsdk:"labels"`
+	AdoptExisting types.Bool `tfsdk:"adopt_existing"`
 
 	// Matches the authentication options provided by terraform-provider-kubernetes
 	Host                  types.String `tfsdk:"host"`
@@ -85,9 +100,14 @@ type ManagedK8sRuntimeResourceModel struct {
 	ProxyUrl              types.String `tfsdk:"proxy_url"`
 	Exec                  *execModel   `tfsdk:"exec"`
 
-	Timeout types.String `tfsdk:"timeout"`
+	Timeout       types.String `tfsdk:"timeout"`
+	KeepNamespace types.Bool   `tfsdk:"keep_namespace"`
+
+	Agent *agentModel `tfsdk:"agent"`
 
-	// TODO: annotation / label passthrough
+	InstallMode   types.String `tfsdk:"install_mode"`
+	ManifestsPath types.String `tfsdk:"manifests_path"`
+	Manifests     types.String `tfsdk:"manifests"`
 
 	//  read-only computed attributes
 	// the runtime_id as read from the agent annotation,
@@ -97,6 +117,10 @@ type ManagedK8sRuntimeResourceModel struct {
 	AgentExternallyManaged types.Bool   `tfsdk:"agent_externally_managed"`
 	// the k8s namespace the agent is running in
 	AgentNamespace types.String `tfsdk:"agent_namespace"`
+	// hashes of the agent k8s objects as they were right after the last apply,
+	// and the objects that changed since, used to detect out of band changes
+	AgentObjectHashes types.Map  `tfsdk:"agent_object_hashes"`
+	AgentDrift        types.List `tfsdk:"agent_drift"`
 }
 
 type execModel struct {
@@ -243,7 +267,7 @@ func (r *ManagedK8sRuntimeResource) initializeConfiguration(ctx context.Context,
 	return cfg, nil
 }
 
-func (r *ManagedK8sRuntimeResource) clientSet(ctx context.Context, diags diag.Diagnostics, planData *ManagedK8sRuntimeResourceModel) (*kubernetes.Clientset, error) {
+func (r *ManagedK8sRuntimeResource) clientSet(ctx context.Context, diags diag.Diagnostics, planData *ManagedK8sRuntimeResourceModel) (kubernetes.Interface, error) {
 	if r.clientset != nil {
 		return r.clientset, nil
 	}
@@ -293,12 +317,19 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 				Optional:            true,
 				NestedObject:        labels.LabelDefinitionNestedObjectResourceSchema(),
 			},
+			"adopt_existing": adoptExistingSchema("runtime"),
 			"timeout": schema.StringAttribute{
-				MarkdownDescription: "How long to wait for the runtime linking to complete. A valid Go duration string, e.g. `10m` or `1h`. Defaults to `10m`",
+				MarkdownDescription: "How long to wait for the agent deployment to roll out and for the runtime linking to complete, and for the agent namespace to be deleted. A valid Go duration string, e.g. `10m` or `1h`. Defaults to `10m`",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("10m"),
 			},
+			"keep_namespace": schema.BoolAttribute{
+				MarkdownDescription: "Leave the agent namespace in place when the runtime is destroyed or the agent moves to another namespace, only the agent objects in it are deleted. Otherwise the namespace is deleted and Terraform waits up to `timeout` for it to be gone, e.g. when it is stuck on finalizers. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"agent_runtime_id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The runtime identifier of the agent",
@@ -308,16 +339,42 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			},
 			"agent_externally_managed": schema.BoolAttribute{
 				Computed:            true,
-				MarkdownDescription: "If the agent has been set to be externally managed. This should be false since this is the managed_k8s_runtime resource -- this is used to detect out of band changes to the agent deployment",
-				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: fmt.Sprintf("If the agent has been set to be externally managed. This is false when `install_mode` is `%s` since Terraform applies the agent, and true when it is `%s` since the manifests are applied outside Terraform -- this is used to detect out of band changes to the agent deployment", agentInstallModeApply, agentInstallModeManifests),
 			},
 			"agent_namespace": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The namespace of the agent",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+			},
+			"agent_object_hashes": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Hashes of the agent Kubernetes objects as they were after the last apply, keyed by `Kind/namespace/name`. For the deployment only the fields set by the provider are hashed",
+			},
+			"agent_drift": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Agent Kubernetes objects that were changed or deleted outside Terraform since the last apply. Drift shows up as a planned change to this attribute, applying it reconciles the objects",
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			},
+			"agent": agentSchema(),
+			"install_mode": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How the agent is installed. `%s` (default) applies the agent objects to the cluster. `%s` only renders them to `manifests` and `manifests_path`, e.g. to commit them to a GitOps repository; Terraform then neither connects to the cluster nor waits for the agent. Switching to `%s` leaves the applied objects in the cluster", agentInstallModeApply, agentInstallModeManifests, agentInstallModeManifests),
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(agentInstallModeApply),
+				Validators: []validator.String{
+					stringvalidator.OneOf(agentInstallModeApply, agentInstallModeManifests),
 				},
 			},
+			"manifests_path": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Local file to write the rendered agent manifests to when `install_mode` is `%s`. The file is only readable by its owner as it contains the agent API token, and is removed on destroy", agentInstallModeManifests),
+				Optional:            true,
+			},
+			"manifests": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Multi-document YAML manifest of the agent namespace, service account, RBAC objects and deployment when `install_mode` is `%s`", agentInstallModeManifests),
+				Computed:            true,
+				Sensitive:           true,
+			},
 			"agent_env": schema.MapAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
@@ -328,31 +385,31 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"host": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The address of the Kubernetes cluster (scheme://hostname:port)",
+				MarkdownDescription: "The address of the Kubernetes cluster (scheme://hostname:port). Can also be set with the `KUBE_HOST` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_HOST"),
 			},
 			"username": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Username for basic authentication to the Kubernetes cluster",
+				MarkdownDescription: "Username for basic authentication to the Kubernetes cluster. Can also be set with the `KUBE_USER` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_USER"),
 			},
 			"password": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Password for basic authentication to the Kubernetes cluster",
+				MarkdownDescription: "Password for basic authentication to the Kubernetes cluster. Can also be set with the `KUBE_PASSWORD` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_PASSWORD"),
 			},
 			"insecure": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Whether server should be accessed without verifying the TLS certificate",
+				MarkdownDescription: "Whether server should be accessed without verifying the TLS certificate. Can also be set with the `KUBE_INSECURE` environment variable",
 				Default:             defaults.EnvBoolValue("KUBE_INSECURE", false),
 			},
 			"tls_server_name": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Server name passed to the server for SNI and is used in the client to check server certificates against",
+				MarkdownDescription: "Server name passed to the server for SNI and is used in the client to check server certificates against. Can also be set with the `KUBE_TLS_SERVER_NAME` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_TLS_SERVER_NAME"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -362,7 +419,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"client_certificate": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "PEM-encoded client certificate for TLS authentication.",
+				MarkdownDescription: "PEM-encoded client certificate for TLS authentication. Can also be set with the `KUBE_CLIENT_CERT_DATA` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_CLIENT_CERT_DATA"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -371,7 +428,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"client_key": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "PEM-encoded client certificate key for TLS authentication.",
+				MarkdownDescription: "PEM-encoded client certificate key for TLS authentication. Can also be set with the `KUBE_CLIENT_KEY_DATA` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_CLIENT_KEY_DATA"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -380,7 +437,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"cluster_ca_certificate": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "PEM-encoded root certificates bundle for TLS authentication.",
+				MarkdownDescription: "PEM-encoded root certificates bundle for TLS authentication. Can also be set with the `KUBE_CLUSTER_CA_CERT_DATA` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_CLUSTER_CA_CERT_DATA"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -390,13 +447,13 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 				ElementType:         types.StringType,
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "A list of paths to kube config files.",
+				MarkdownDescription: "A list of paths to kube config files. Can also be set with the `KUBE_CONFIG_PATHS` environment variable, separated like `PATH`",
 				Default:             defaults.EnvPathListValue("KUBE_CONFIG_PATHS"),
 			},
 			"config_path": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Path to the kube config file.",
+				MarkdownDescription: "Path to the kube config file. Can also be set with the `KUBE_CONFIG_PATH` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_CONFIG_PATH"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -405,7 +462,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"config_context": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Context to use from the kube config file.",
+				MarkdownDescription: "Context to use from the kube config file. Can also be set with the `KUBE_CTX` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_CTX"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -414,7 +471,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"config_context_auth_info": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Authentication info context of the kube config (name of the kubeconfig user, `--user` flag in `kubectl`).",
+				MarkdownDescription: "Authentication info context of the kube config (name of the kubeconfig user, `--user` flag in `kubectl`). Can also be set with the `KUBE_CTX_AUTH_INFO` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_CTX_AUTH_INFO"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -423,7 +480,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"config_context_cluster": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Cluster context of the kube config (name of the kubeconfig cluster, `--cluster` flag in `kubectl`).",
+				MarkdownDescription: "Cluster context of the kube config (name of the kubeconfig cluster, `--cluster` flag in `kubectl`). Can also be set with the `KUBE_CTX_CLUSTER` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_CTX_CLUSTER"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -432,7 +489,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"token": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Token to authenticate an service account",
+				MarkdownDescription: "Token to authenticate an service account. Can also be set with the `KUBE_TOKEN` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_TOKEN"),
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
@@ -444,7 +501,10 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 				Attributes: map[string]schema.Attribute{
 					"api_version": schema.StringAttribute{
 						Required:            true,
-						MarkdownDescription: "API version of the exec credential plugin",
+						MarkdownDescription: fmt.Sprintf("API version of the exec credential plugin, `%s`", strings.Join(k8sExecApiVersions, "` or `")),
+						Validators: []validator.String{
+							stringvalidator.OneOf(k8sExecApiVersions...),
+						},
 					},
 					"command": schema.StringAttribute{
 						Required:            true,
@@ -465,7 +525,7 @@ The agent will be installed as a Kubernetes deployment in the specified namespac
 			"proxy_url": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Proxy URL to use when accessing the Kubernetes cluster",
+				MarkdownDescription: "Proxy URL to use when accessing the Kubernetes cluster. Can also be set with the `KUBE_PROXY_URL` environment variable",
 				Default:             defaults.EnvStringValue("KUBE_PROXY_URL"),
 			},
 		},
@@ -478,21 +538,86 @@ func (r *ManagedK8sRuntimeResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = env_pb.NewEnvironmentManagerClient(conn)
+	r.client = clients.Environment
+}
+
+func (r *ManagedK8sRuntimeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var installMode, manifestsPath types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("install_mode"), &installMode)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("manifests_path"), &manifestsPath)...)
+	if !installMode.IsUnknown() && installMode.ValueString() != agentInstallModeManifests && !manifestsPath.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("manifests_path"), "Invalid Attribute Combination", fmt.Sprintf("`manifests_path` can only be set when `install_mode` is `%s`", agentInstallModeManifests))
+	}
+
+	var data ManagedK8sRuntimeResourceModel
+	if diags := req.Config.Get(ctx, &data); !diags.HasError() {
+		resp.Diagnostics.Append(validateK8sAuthConfig(&data)...)
+	}
+
+	var agent *agentModel
+	if diags := req.Config.GetAttribute(ctx, path.Root("agent"), &agent); diags.HasError() {
+		// parts of the agent block are unknown, it is validated again on apply
+		return
+	}
+	_, diags := agentOptionsFromModel(ctx, agent)
+	resp.Diagnostics.Append(diags...)
 }
 
-func getDeploymentRuntimeId(ctx context.Context, clientSet *kubernetes.Clientset, data *ManagedK8sRuntimeResourceModel) (bool, string, error) {
+func (r *ManagedK8sRuntimeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var agent *agentModel
+	namespace := types.StringUnknown()
+	if diags := req.Plan.GetAttribute(ctx, path.Root("agent"), &agent); !diags.HasError() {
+		if agent == nil || !agent.Namespace.IsUnknown() {
+			namespace = types.StringValue(agentNamespace(agent))
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("agent_namespace"), namespace)...)
+
+	var installMode types.String
+	externallyManaged := types.BoolUnknown()
+	if diags := req.Plan.GetAttribute(ctx, path.Root("install_mode"), &installMode); !diags.HasError() && !installMode.IsUnknown() {
+		externallyManaged = types.BoolValue(agentInstallMode(&ManagedK8sRuntimeResourceModel{InstallMode: installMode}) == agentInstallModeManifests)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("agent_externally_managed"), externallyManaged)...)
+}
+
+// agentTimeout returns how long to wait for the agent objects to roll out or be deleted.
+func agentTimeout(data *ManagedK8sRuntimeResourceModel) (time.Duration, error) {
+	if data.Timeout.IsNull() || data.Timeout.IsUnknown() {
+		return 10 * time.Minute, nil
+	}
+	timeout, err := time.ParseDuration(data.Timeout.ValueString())
+	if err != nil {
+		return 0, errors.Wrapf(err, "Unable to parse timeout duration")
+	}
+	return timeout, nil
+}
+
+// agentInstallMode returns the install mode, state written before install_mode existed applied the agent.
+func agentInstallMode(data *ManagedK8sRuntimeResourceModel) string {
+	if data.InstallMode.IsNull() || data.InstallMode.IsUnknown() {
+		return agentInstallModeApply
+	}
+	return data.InstallMode.ValueString()
+}
+
+func getDeploymentRuntimeId(ctx context.Context, clientSet kubernetes.Interface, data *ManagedK8sRuntimeResourceModel) (bool, string, error) {
 	agentDeploy, err := clientSet.AppsV1().Deployments(data.AgentNamespace.ValueString()).Get(ctx, agentDeploymentName, metav1.GetOptions{})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
@@ -514,7 +639,7 @@ func prodvanaClusterData(ctx context.Context, client env_pb.EnvironmentManagerCl
 	return resp.Cluster, nil
 }
 
-func readManagedK8sRuntimeData(ctx context.Context, diags diag.Diagnostics, client env_pb.EnvironmentManagerClient, clientSet *kubernetes.Clientset, data *ManagedK8sRuntimeResourceModel, maybeCluster *env_pb.ListClustersResp_ClusterInfo) error {
+func readManagedK8sRuntimeData(ctx context.Context, diags diag.Diagnostics, client env_pb.EnvironmentManagerClient, clientSet kubernetes.Interface, data *ManagedK8sRuntimeResourceModel, maybeCluster *env_pb.ListClustersResp_ClusterInfo) error {
 	 var cluster *env_pb.ListClustersResp_ClusterInfo
 	 if maybeCluster != nil {
 		 cluster = maybeCluster
@@ -555,6 +680,24 @@ func readManagedK8sRuntimeData(ctx context.Context, diags diag.Diagnostics, clie
 		data.AgentExternallyManaged = types.BoolValue(false)
 	}
 
+	if agentInstallMode(data) == agentInstallModeManifests {
+		if !data.ManifestsPath.IsNull() {
+			exists, err := agentManifestsExist(data.ManifestsPath.ValueString())
+			if err != nil {
+				return err
+			}
+			if !exists {
+				// removed outside Terraform, planning manifests_path again writes it back
+				data.ManifestsPath = types.StringNull()
+			}
+		}
+		// the agent objects are applied outside Terraform, there is nothing to compare against
+		data.AgentRuntimeId = types.StringNull()
+		data.AgentObjectHashes = types.MapNull(types.StringType)
+		data.AgentDrift = types.ListValueMust(types.StringType, []attr.Value{})
+		return nil
+	}
+
 	found, runtimeId, err := getDeploymentRuntimeId(ctx, clientSet, data)
 	if err != nil {
 		return errors.Wrapf(err, "Unable to read agent deployment for %s", data.Name.ValueString())
@@ -564,45 +707,267 @@ func readManagedK8sRuntimeData(ctx context.Context, diags diag.Diagnostics, clie
 	} else {
 		data.AgentRuntimeId = types.StringNull()
 	}
+
+	expected, objectsDiags := stateAgentObjects(ctx, data)
+	diags.Append(objectsDiags...)
+	if diags.HasError() {
+		return errors.Errorf("Failed to convert agent: %v", diags.Errors())
+	}
+	liveHashes, err := agentObjectHashes(ctx, clientSet, expected)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read agent objects for %s", data.Name.ValueString())
+	}
+	appliedHashes := stringMapFromTerraform(ctx, data.AgentObjectHashes, &diags)
+	if appliedHashes == nil {
+		// just applied, or applied by a provider version without drift detection
+		appliedHashes = liveHashes
+		hashesValue, valueDiags := types.MapValueFrom(ctx, types.StringType, liveHashes)
+		diags.Append(valueDiags...)
+		data.AgentObjectHashes = hashesValue
+	}
+	driftValue, valueDiags := types.ListValueFrom(ctx, types.StringType, agentDrift(appliedHashes, liveHashes))
+	diags.Append(valueDiags...)
+	if diags.HasError() {
+		return errors.Errorf("Failed to convert agent object hashes: %v", diags.Errors())
+	}
+	data.AgentDrift = driftValue
 	return nil
 }
 
-func (r *ManagedK8sRuntimeResource) refresh(ctx context.Context, diags diag.Diagnostics, clientset *kubernetes.Clientset, data *ManagedK8sRuntimeResourceModel, maybeCluster *env_pb.ListClustersResp_ClusterInfo) error {
+func (r *ManagedK8sRuntimeResource) refresh(ctx context.Context, diags diag.Diagnostics, clientset kubernetes.Interface, data *ManagedK8sRuntimeResourceModel, maybeCluster *env_pb.ListClustersResp_ClusterInfo) error {
 	return readManagedK8sRuntimeData(ctx, diags, r.client, clientset, data, maybeCluster)
 }
 
-func deleteKubernetesObjects(ctx context.Context, namespace string, clientSet *kubernetes.Clientset) error {
+// stateAgentObjects renders the agent objects recorded in state, these are
+// the objects that must be cleaned up before recreating or removing the agent.
+func stateAgentObjects(ctx context.Context, data *ManagedK8sRuntimeResourceModel) (*agentObjects, diag.Diagnostics) {
+	opts, diags := agentOptionsFromModel(ctx, data.Agent)
+	if ns := data.AgentNamespace.ValueString(); ns != "" {
+		opts.namespace = ns
+	}
+	return buildAgentObjects(opts), diags
+}
+
+func applyPatchOptions() metav1.PatchOptions {
+	// take over fields last written by someone else, the provider owns the agent objects
+	force := true
+	return metav1.PatchOptions{
+		FieldManager: agentFieldManager,
+		Force:        &force,
+	}
+}
+
+// applyKubernetesObjects reconciles the agent objects with server-side apply,
+// so changes roll out in place instead of recreating the objects.
+func applyKubernetesObjects(ctx context.Context, objects *agentObjects, clientSet kubernetes.Interface) error {
+	apply := func(obj interface{}, description string, patch func(data []byte) error) error {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to encode %s", description)
+		}
+		if err := patch(data); err != nil {
+			return errors.Wrapf(err, "Failed to apply %s", description)
+		}
+		return nil
+	}
+
+	err := apply(objects.namespace, "agent namespace", func(data []byte) error {
+		_, err := clientSet.CoreV1().Namespaces().Patch(ctx, objects.namespace.Name, k8s_types.ApplyPatchType, data, applyPatchOptions())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	err = apply(objects.serviceAccount, "agent service account", func(data []byte) error {
+		_, err := clientSet.CoreV1().ServiceAccounts(objects.serviceAccount.Namespace).Patch(ctx, objects.serviceAccount.Name, k8s_types.ApplyPatchType, data, applyPatchOptions())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if objects.clusterRole != nil {
+		err = apply(objects.clusterRole, "agent cluster role", func(data []byte) error {
+			_, err := clientSet.RbacV1().ClusterRoles().Patch(ctx, objects.clusterRole.Name, k8s_types.ApplyPatchType, data, applyPatchOptions())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if objects.clusterRoleBinding != nil {
+		err = apply(objects.clusterRoleBinding, "agent cluster role binding", func(data []byte) error {
+			_, err := clientSet.RbacV1().ClusterRoleBindings().Patch(ctx, objects.clusterRoleBinding.Name, k8s_types.ApplyPatchType, data, applyPatchOptions())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, role := range objects.roles {
+		err = apply(role, fmt.Sprintf("agent role in namespace %s", role.Namespace), func(data []byte) error {
+			_, err := clientSet.RbacV1().Roles(role.Namespace).Patch(ctx, role.Name, k8s_types.ApplyPatchType, data, applyPatchOptions())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, roleBinding := range objects.roleBindings {
+		err = apply(roleBinding, fmt.Sprintf("agent role binding in namespace %s", roleBinding.Namespace), func(data []byte) error {
+			_, err := clientSet.RbacV1().RoleBindings(roleBinding.Namespace).Patch(ctx, roleBinding.Name, k8s_types.ApplyPatchType, data, applyPatchOptions())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Applying deployment: %#v", objects.deployment))
+	return apply(objects.deployment, "agent deployment", func(data []byte) error {
+		_, err := clientSet.AppsV1().Deployments(objects.deployment.Namespace).Patch(ctx, objects.deployment.Name, k8s_types.ApplyPatchType, data, applyPatchOptions())
+		return err
+	})
+}
+
+// deleteKubernetesObjects deletes the agent objects that are set in objects.
+func deleteKubernetesObjects(ctx context.Context, objects *agentObjects, clientSet kubernetes.Interface, timeout time.Duration) error {
 	tflog.Trace(ctx, "Deleting agent k8s objects")
-	err := clientSet.AppsV1().Deployments(namespace).Delete(ctx, agentDeploymentName, metav1.DeleteOptions{})
-	if err != nil && !k8s_errors.IsNotFound(err) {
-		return errors.Wrapf(err, "Failed to delete agent deployment")
+	if objects.deployment != nil {
+		err := clientSet.AppsV1().Deployments(objects.deployment.Namespace).Delete(ctx, objects.deployment.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete agent deployment")
+		}
 	}
-	err = clientSet.RbacV1().ClusterRoleBindings().Delete(ctx, clusterRoleBindingName, metav1.DeleteOptions{})
-	if err != nil && !k8s_errors.IsNotFound(err) {
-		return errors.Wrapf(err, "Failed to delete agent cluster role binding")
+	if objects.clusterRoleBinding != nil {
+		err := clientSet.RbacV1().ClusterRoleBindings().Delete(ctx, objects.clusterRoleBinding.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete agent cluster role binding")
+		}
 	}
-	err = clientSet.CoreV1().ServiceAccounts(namespace).Delete(ctx, serviceAccountName, metav1.DeleteOptions{})
-	if err != nil && !k8s_errors.IsNotFound(err) {
-		return errors.Wrapf(err, "Failed to delete agent service account")
+	// only the ClusterRole created by the provider, an existing one is left alone
+	if objects.clusterRole != nil {
+		err := clientSet.RbacV1().ClusterRoles().Delete(ctx, objects.clusterRole.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete agent cluster role")
+		}
 	}
+	for _, roleBinding := range objects.roleBindings {
+		err := clientSet.RbacV1().RoleBindings(roleBinding.Namespace).Delete(ctx, roleBinding.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete agent role binding in namespace %s", roleBinding.Namespace)
+		}
+	}
+	for _, role := range objects.roles {
+		err := clientSet.RbacV1().Roles(role.Namespace).Delete(ctx, role.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete agent role in namespace %s", role.Namespace)
+		}
+	}
+	if objects.serviceAccount != nil {
+		err := clientSet.CoreV1().ServiceAccounts(objects.serviceAccount.Namespace).Delete(ctx, objects.serviceAccount.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return errors.Wrapf(err, "Failed to delete agent service account")
+		}
+	}
+	if objects.namespace == nil {
+		return nil
+	}
+	namespace := objects.namespace.Name
 	deletePropagation := metav1.DeletePropagationForeground
 	graceSeconds := int64(0)
-	err = clientSet.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{
+	err := clientSet.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{
 		GracePeriodSeconds: &graceSeconds,
 		PropagationPolicy:  &deletePropagation,
 	})
 	if err != nil && !k8s_errors.IsNotFound(err) {
 		return errors.Wrapf(err, "Failed to delete agent namespace")
 	}
-	// wait for namespace to be fully deleted
-	for {
-		_, err := clientSet.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
-		if err != nil && k8s_errors.IsNotFound(err) {
-			break
+	return waitForNamespaceDeletion(ctx, clientSet, namespace, timeout)
+}
+
+// applyAgent installs or updates the agent objects in the cluster and waits
+// for the agent to come up, it returns false if there was nothing to change.
+func (r *ManagedK8sRuntimeResource) applyAgent(ctx context.Context, diags diag.Diagnostics, clientSet kubernetes.Interface, planData, stateData *ManagedK8sRuntimeResourceModel, objects *agentObjects, clusterId string) (bool, error) {
+	timeout, err := agentTimeout(planData)
+	if err != nil {
+		return false, err
+	}
+	create := stateData == nil
+	if create {
+		found, runtimeId, err := getDeploymentRuntimeId(ctx, clientSet, planData)
+		if err != nil {
+			return false, errors.Wrap(err, "unable to verify kubernetes agent state of new cluster")
+		}
+		if found && runtimeId != clusterId {
+			return false, errors.Errorf("found existing agent deployment in cluster with a different runtime id: %s", runtimeId)
+		}
+	} else {
+		// the only changes we must handle are labels, the agent block, or the agent_env attribute as
+		// this needs to be passed on to the apiserver so it can update the agent
+		// properly, and then here we should apply the deployment with the new env vars
+		// Why apply here instead of letting apiserver handle it in its own update loop?
+		// The env may contain proxy information, and if the proxy is changed, the agent
+		// may no longer be able to talk with apiserver and so cannot be updated FROM apiserver.
+		planOpts, optsDiags := agentOptionsFromModel(ctx, planData.Agent)
+		diags.Append(optsDiags...)
+		stateOpts, optsDiags := agentOptionsFromModel(ctx, stateData.Agent)
+		diags.Append(optsDiags...)
+		if diags.HasError() {
+			return false, errors.Errorf("Failed to convert agent: %v", diags.Errors())
+		}
+
+		var drift []string
+		diags.Append(stateData.AgentDrift.ElementsAs(ctx, &drift, false)...)
+		if diags.HasError() {
+			return false, errors.Errorf("Failed to convert agent_drift: %v", diags.Errors())
+		}
+
+		if planData.AgentEnv.Equal(stateData.AgentEnv) && planData.Labels.Equal(stateData.Labels) && reflect.DeepEqual(planOpts, stateOpts) && len(drift) == 0 && agentInstallMode(stateData) == agentInstallModeApply {
+			planData.AgentObjectHashes = stateData.AgentObjectHashes
+			return false, nil
+		}
+		tflog.Trace(ctx, "agent_env, agent or the agent objects changed, must apply the agent objects")
+		stateObjects, objectsDiags := stateAgentObjects(ctx, stateData)
+		diags.Append(objectsDiags...)
+		if diags.HasError() {
+			return false, errors.Errorf("Failed to convert agent: %v", diags.Errors())
+		}
+		// e.g. a moved namespace or a different rbac mode
+		stale := staleAgentObjects(stateObjects, objects)
+		if planData.KeepNamespace.ValueBool() {
+			stale.namespace = nil
+		}
+		err := deleteKubernetesObjects(ctx, stale, clientSet, timeout)
+		if err != nil {
+			return false, err
 		}
 	}
 
-	return nil
+	err = applyKubernetesObjects(ctx, objects, clientSet)
+	if err != nil {
+		return false, err
+	}
+
+	err = waitForAgentRollout(ctx, clientSet, objects.deployment.Namespace, timeout)
+	if err != nil {
+		return false, err
+	}
+	rolledOut := time.Now()
+
+	// record the objects as applied on the next refresh
+	planData.AgentObjectHashes = types.MapNull(types.StringType)
+
+	// the replaced agent may have sent a heartbeat moments ago, only one sent after the rollout proves the new agent works
+	err = WaitForClusterHeartbeatSince(ctx, r.client, clusterId, planData.Name.ValueString(), rolledOut, planData.Timeout.ValueString())
+	if err != nil {
+		return false, errors.Wrapf(err, "Runtime linking failed")
+	}
+	return true, nil
 }
 
 func (r *ManagedK8sRuntimeResource) createOrUpdate(ctx context.Context, diags diag.Diagnostics, planData, stateData *ManagedK8sRuntimeResourceModel) error {
@@ -632,7 +997,8 @@ func (r *ManagedK8sRuntimeResource) createOrUpdate(ctx context.Context, diags di
 	req.Auth = &env_pb.ClusterAuth{
 		AuthOneof: &env_pb.ClusterAuth_K8S{
 			K8S: &env_pb.ClusterAuth_K8SAuth{
-				AgentExternallyManaged: false,
+				// in manifests mode the agent is applied outside Terraform, like prodvana_k8s_runtime
+				AgentExternallyManaged: agentInstallMode(planData) == agentInstallModeManifests,
 				AgentEnv:               agentEnv,
 			},
 		},
@@ -643,162 +1009,51 @@ func (r *ManagedK8sRuntimeResource) createOrUpdate(ctx context.Context, diags di
 	}
 	planData.Id = types.StringValue(linkResp.ClusterId)
 
-	clientSet, err := r.clientSet(ctx, diags, planData)
-	if err != nil {
-		return err
+	opts, optsDiags := agentOptionsFromModel(ctx, planData.Agent)
+	diags.Append(optsDiags...)
+	if diags.HasError() {
+		return errors.Errorf("Failed to convert agent: %v", diags.Errors())
 	}
+	opts.runtimeId = linkResp.ClusterId
+	opts.image = linkResp.K8SAgentImage
+	opts.args = linkResp.K8SAgentArgs
+	opts.env = agentEnv
+	planData.AgentNamespace = types.StringValue(opts.namespace)
 
-	// TODO(mike): support supplying custom namespace name
-	namespace := defaultAgentNamespace
-	planData.AgentNamespace = types.StringValue(namespace)
+	objects := buildAgentObjects(opts)
 
-	create := stateData == nil
-	if create {
-		found, runtimeId, err := getDeploymentRuntimeId(ctx, clientSet, planData)
+	var clientSet kubernetes.Interface
+	if agentInstallMode(planData) == agentInstallModeManifests {
+		manifests, err := renderAgentManifests(objects)
 		if err != nil {
-			return errors.Wrap(err, "unable to verify kubernetes agent state of new cluster")
+			return err
+		}
+		if stateData != nil && !stateData.ManifestsPath.IsNull() && !stateData.ManifestsPath.Equal(planData.ManifestsPath) {
+			if err := removeAgentManifests(stateData.ManifestsPath.ValueString()); err != nil {
+				return err
+			}
 		}
-		if found && runtimeId != linkResp.ClusterId {
-			return errors.Errorf("found existing agent deployment in cluster with a different runtime id: %s", runtimeId)
+		if !planData.ManifestsPath.IsNull() {
+			if err := writeAgentManifests(planData.ManifestsPath.ValueString(), manifests); err != nil {
+				return err
+			}
 		}
+		planData.Manifests = types.StringValue(manifests)
+		// nothing to wait for, the agent is installed once the manifests are applied outside Terraform
 	} else {
-		// the only changes we must handle are labels, or the agent_env attribute as
-		// this needs to be passed on to the apiserver so it can update the agent
-		// properly, and then here we should recreate the deployment with the new env vars
-		// Why recreate here instead of letting apiserver handle it in its own update loop?
-		// The env may contain proxy information, and if the proxy is changed, the agent
-		// may no longer be able to talk with apiserver and so cannot be updated FROM apiserver.
-
-		if agentEnvValue.Equal(stateData.AgentEnv) && planData.Labels.Equal(stateData.Labels) {
-			// nothing to do
-			return nil
+		clientSet, err = r.clientSet(ctx, diags, planData)
+		if err != nil {
+			return err
 		}
-		tflog.Trace(ctx, "agent_env changed, must recreate the agent deployment")
-		err = deleteKubernetesObjects(ctx, namespace, clientSet)
+		planData.Manifests = types.StringNull()
+		changed, err := r.applyAgent(ctx, diags, clientSet, planData, stateData, objects, linkResp.ClusterId)
 		if err != nil {
 			return err
 		}
-	}
-
-	// namespace
-	namespaceSpec := &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: namespace,
-		},
-	}
-	_, err = clientSet.CoreV1().Namespaces().Create(ctx, namespaceSpec, metav1.CreateOptions{})
-	if err != nil && !k8s_errors.IsAlreadyExists(err) {
-		return errors.Wrapf(err, "Failed to create agent namespace")
-	}
-
-	// service account
-	saSpec := &corev1.ServiceAccount{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      serviceAccountName,
-			Namespace: namespaceSpec.Name,
-		},
-	}
-	_, err = clientSet.CoreV1().ServiceAccounts(saSpec.Namespace).Create(ctx, saSpec, metav1.CreateOptions{})
-	if err != nil && !k8s_errors.IsAlreadyExists(err) {
-		return errors.Wrapf(err, "Failed to create agent service account")
-	}
-
-	// service account cluster role binding
-	roleBindingSpec := &rbacv1.ClusterRoleBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: clusterRoleBindingName,
-		},
-		Subjects: []rbacv1.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Name:      saSpec.Name,
-				Namespace: saSpec.Namespace,
-			},
-		},
-		RoleRef: rbacv1.RoleRef{
-			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "ClusterRole",
-			Name:     "cluster-admin",
-		},
-	}
-
-	_, err = clientSet.RbacV1().ClusterRoleBindings().Create(ctx, roleBindingSpec, metav1.CreateOptions{})
-	if err != nil && !k8s_errors.IsAlreadyExists(err) {
-		return errors.Wrapf(err, "Failed to create agent cluster role binding")
-	}
-
-	env := []corev1.EnvVar{
-		{
-			Name:  "PVN_NAMESPACE",
-			Value: namespaceSpec.Name,
-		},
-		{
-			Name:  "PVN_RELEASE_CHANNEL",
-			Value: "prodvana",
-		},
-	}
-	for k, v := range agentEnv {
-		env = append(env, corev1.EnvVar{
-			Name:  k,
-			Value: v,
-		})
-	}
-
-	// deployment
-	var replicas int32 = 1
-	deploymentSpec := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      agentDeploymentName,
-			Namespace: namespaceSpec.Name,
-			Annotations: map[string]string{
-				agentRuntimeIdAnnotation: linkResp.ClusterId,
-			},
-		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app": agentDeploymentName,
-				},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":                 agentDeploymentName,
-						"prodvana.io/service": agentDeploymentName,
-					},
-				},
-				Spec: corev1.PodSpec{
-					ServiceAccountName: saSpec.Name,
-					Containers: []corev1.Container{
-						{
-							Name:            "default",
-							Args:            linkResp.K8SAgentArgs,
-							Env:             env,
-							Image:           linkResp.K8SAgentImage,
-							ImagePullPolicy: corev1.PullAlways,
-							Ports: []corev1.ContainerPort{
-								{
-									ContainerPort: 5100,
-									Protocol:      corev1.ProtocolTCP,
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-
-	tflog.Info(ctx, fmt.Sprintf("Creating new deployment: %#v", deploymentSpec))
-	_, err = clientSet.AppsV1().Deployments(namespaceSpec.Name).Create(ctx, deploymentSpec, metav1.CreateOptions{})
-	if err != nil && !k8s_errors.IsAlreadyExists(err) {
-		return errors.Wrapf(err, "Failed to create agent deployment")
-	}
-
-	err = WaitForClusterWithTimeout(ctx, r.client, linkResp.ClusterId, planData.Name.ValueString(), planData.Timeout.ValueString())
-	if err != nil && !k8s_errors.IsAlreadyExists(err) {
-		return errors.Wrapf(err, "Runtime linking failed")
+		if !changed {
+			// nothing to do
+			return nil
+		}
 	}
 
 	getResp, err := r.client.GetCluster(ctx, &env_pb.GetClusterReq{
@@ -836,6 +1091,16 @@ func (r *ManagedK8sRuntimeResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	if !data.AdoptExisting.ValueBool() {
+		_, err := r.client.GetCluster(ctx, &env_pb.GetClusterReq{
+			Runtime: data.Name.ValueString(),
+		})
+		if err := checkNotExists("runtime", data.Name.ValueString(), "", err); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create runtime, got error: %s", err))
+			return
+		}
+	}
+
 	tflog.Trace(ctx, "creating runtime resource")
 	err := r.createOrUpdate(ctx, resp.Diagnostics, data, nil)
 	if err != nil {
@@ -869,10 +1134,13 @@ func (r *ManagedK8sRuntimeResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	clientSet, err := r.clientSet(ctx, resp.Diagnostics, data)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client set, got error: %s", err))
-		return
+	var clientSet kubernetes.Interface
+	if agentInstallMode(data) == agentInstallModeApply {
+		clientSet, err = r.clientSet(ctx, resp.Diagnostics, data)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client set, got error: %s", err))
+			return
+		}
 	}
 	err = r.refresh(ctx, resp.Diagnostics, clientSet, data, cluster)
 	if err != nil {
@@ -928,12 +1196,37 @@ func (r *ManagedK8sRuntimeResource) Delete(ctx context.Context, req resource.Del
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete runtime, got error: %s", err))
 		return
 	}
+	if agentInstallMode(data) == agentInstallModeManifests {
+		// the agent objects were applied outside Terraform and are removed the same way
+		if !data.ManifestsPath.IsNull() {
+			err = removeAgentManifests(data.ManifestsPath.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete runtime, got error: %s", err))
+				return
+			}
+		}
+		tflog.Trace(ctx, "deleted runtime resource")
+		return
+	}
 	clientSet, err := r.clientSet(ctx, resp.Diagnostics, data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to kubernetes create client set, got error: %s", err))
 		return
 	}
-	err = deleteKubernetesObjects(ctx, data.AgentNamespace.ValueString(), clientSet)
+	stateObjects, objectsDiags := stateAgentObjects(ctx, data)
+	resp.Diagnostics.Append(objectsDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.KeepNamespace.ValueBool() {
+		stateObjects.namespace = nil
+	}
+	timeout, err := agentTimeout(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete runtime, got error: %s", err))
+		return
+	}
+	err = deleteKubernetesObjects(ctx, stateObjects, clientSet, timeout)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete runtime, got error: %s", err))
 		return
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -38,6 +40,8 @@ func TestAccManagedK8sRuntimeResource(t *testing.T) {
 					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "name", runtimeName),
 					resource.TestCheckResourceAttrSet("prodvana_managed_k8s_runtime."+runtimeName, "id"),
 					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "agent_env.PROXY", "foo"),
+					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "agent_object_hashes.%", "4"),
+					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "agent_drift.#", "0"),
 					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "labels.0.label", "foo"),
 					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "labels.0.value", "bar"),
 					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "labels.1.label", "baz"),
@@ -67,6 +71,61 @@ func TestAccManagedK8sRuntimeResource(t *testing.T) {
 	})
 }
 
+func TestAccManagedK8sRuntimeResourceManifests(t *testing.T) {
+	runtimeName := uniqueTestName("managed-k8s-tests")
+	manifestsPath := filepath.Join(t.TempDir(), "agent.yaml")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if _, err := os.Stat(manifestsPath); !os.IsNotExist(err) {
+				return fmt.Errorf("expected %s to be removed, got %v", manifestsPath, err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccManagedK8sRuntimeResourceManifests(runtimeName, manifestsPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "install_mode", "manifests"),
+					resource.TestCheckResourceAttrSet("prodvana_managed_k8s_runtime."+runtimeName, "manifests"),
+					resource.TestCheckNoResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "agent_runtime_id"),
+					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "agent_drift.#", "0"),
+					resource.TestCheckResourceAttr("prodvana_managed_k8s_runtime."+runtimeName, "agent_externally_managed", "true"),
+					func(s *terraform.State) error {
+						content, err := os.ReadFile(manifestsPath)
+						if err != nil {
+							return err
+						}
+						expected := s.RootModule().Resources["prodvana_managed_k8s_runtime."+runtimeName].Primary.Attributes["manifests"]
+						if string(content) != expected {
+							return fmt.Errorf("expected %s to contain the rendered manifests", manifestsPath)
+						}
+						return nil
+					},
+				),
+			},
+			// Removing the file outside Terraform writes it back
+			{
+				PreConfig: func() {
+					if err := os.Remove(manifestsPath); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccManagedK8sRuntimeResourceManifests(runtimeName, manifestsPath),
+				Check: func(s *terraform.State) error {
+					if _, err := os.Stat(manifestsPath); err != nil {
+						return fmt.Errorf("expected %s to be written back, got %v", manifestsPath, err)
+					}
+					return nil
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
 func TestAccManagedK8sRuntimeResourceLabels(t *testing.T) {
 	runtimeName := uniqueTestName("managed-k8s-tests")
 	resource.Test(t, resource.TestCase{
@@ -410,6 +469,16 @@ resource "prodvana_managed_k8s_runtime" "%[1]s" {
 `, name, proxy, labelsStr)
 }
 
+func testAccManagedK8sRuntimeResourceManifests(name string, manifestsPath string) string {
+	return fmt.Sprintf(`
+resource "prodvana_managed_k8s_runtime" "%[1]s" {
+  name = %[1]q
+  install_mode = "manifests"
+  manifests_path = %[2]q
+}
+`, name, manifestsPath)
+}
+
 func testAccManagedK8sRuntimeResourceConfigPath(name string, configPath, context string) string {
 	return fmt.Sprintf(`
 resource "prodvana_managed_k8s_runtime" "%[1]s" {
@@ -0,0 +1,709 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	version_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProtectionResource{}
+var _ resource.ResourceWithImportState = &ProtectionResource{}
+var _ resource.ResourceWithValidateConfig = &ProtectionResource{}
+var _ resource.ResourceWithModifyPlan = &ProtectionResource{}
+
+func NewProtectionResource() resource.Resource {
+	return &ProtectionResource{}
+}
+
+// ProtectionResource defines the resource implementation.
+type ProtectionResource struct {
+	client  prot_pb.ProtectionManagerClient
+	planned *plannedProtections
+}
+
+// ProtectionResourceModel describes the resource data model.
+type ProtectionResourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+
+	Task             *protectionTask             `tfsdk:"task"`
+	KubernetesConfig *protectionKubernetesConfig `tfsdk:"kubernetes_config"`
+	PollInterval     types.String                `tfsdk:"poll_interval"`
+	Timeout          types.String                `tfsdk:"timeout"`
+	Env              map[string]*envValue        `tfsdk:"env"`
+	Parameters       []*protectionParameter      `tfsdk:"parameters"`
+}
+
+type protectionTask struct {
+	Image      types.String   `tfsdk:"image"`
+	Cmd        []types.String `tfsdk:"cmd"`
+	Entrypoint []types.String `tfsdk:"entrypoint"`
+}
+
+type protectionKubernetesConfig struct {
+	Inlined types.String `tfsdk:"inlined"`
+}
+
+type protectionParameter struct {
+	Name          types.String          `tfsdk:"name"`
+	Description   types.String          `tfsdk:"description"`
+	Required      types.Bool            `tfsdk:"required"`
+	Type          types.String          `tfsdk:"type"`
+	DefaultValue  types.String          `tfsdk:"default_value"`
+	ImageRegistry *serviceImageRegistry `tfsdk:"image_registry"`
+}
+
+// Parameter types a protection can declare, each one matches the value
+// attribute used to pass it in a protection attachment.
+const (
+	parameterTypeString      = "string"
+	parameterTypeInt         = "int"
+	parameterTypeDockerImage = "docker_image"
+	parameterTypeSecret      = "secret"
+)
+
+var parameterTypes = []string{
+	parameterTypeString,
+	parameterTypeInt,
+	parameterTypeDockerImage,
+	parameterTypeSecret,
+}
+
+func (r *ProtectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_protection"
+}
+
+func (r *ProtectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `This resource allows you to manage a Prodvana [Protection](https://docs.prodvana.io/docs/protections) definition.
+Protections are referenced by name from the ` + "`protections`" + ` blocks of release channels and services.
+
+Prodvana does not support deleting protections, destroying this resource only removes it from Terraform state.
+`,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Protection name",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Protection identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Current protection version",
+				Computed:            true,
+			},
+			"task": schema.SingleNestedAttribute{
+				MarkdownDescription: "Run the protection as a container. Exactly one of `task` or `kubernetes_config` must be set",
+				Optional:            true,
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRoot("kubernetes_config")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"image": schema.StringAttribute{
+						MarkdownDescription: "image to run, may reference parameters",
+						Required:            true,
+					},
+					"cmd": schema.ListAttribute{
+						MarkdownDescription: "command to run, overrides the image's CMD",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"entrypoint": schema.ListAttribute{
+						MarkdownDescription: "entrypoint to run, overrides the image's ENTRYPOINT",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+			"kubernetes_config": schema.SingleNestedAttribute{
+				MarkdownDescription: "Run the protection from a Kubernetes job manifest. Exactly one of `task` or `kubernetes_config` must be set",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"inlined": schema.StringAttribute{
+						MarkdownDescription: "Kubernetes manifest, may reference parameters",
+						Required:            true,
+					},
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "How often to run the protection. A valid Go duration string, e.g. `30s` or `5m`",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long a single run of the protection may take. A valid Go duration string, e.g. `10m` or `1h`",
+				Optional:            true,
+			},
+			"env": schema.MapNestedAttribute{
+				MarkdownDescription: "environment variables passed to the protection",
+				Optional:            true,
+				NestedObject:        envValueNestedObjectSchema(),
+			},
+			"parameters": schema.ListNestedAttribute{
+				MarkdownDescription: "Parameters the protection accepts, set through `ref.parameters` when the protection is attached",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "name of the parameter",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "description of the parameter",
+							Optional:            true,
+						},
+						"required": schema.BoolAttribute{
+							MarkdownDescription: "whether every attachment must set this parameter",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: fmt.Sprintf("type of the parameter, one of (%s). Attachments pass it with the matching `<type>_value` attribute, or `docker_image_tag_value` for `docker_image`", strings.Join(parameterTypes, ", ")),
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(parameterTypes...),
+							},
+						},
+						"default_value": schema.StringAttribute{
+							MarkdownDescription: "default value of the parameter, the default tag for `docker_image`. Not supported for `secret`",
+							Optional:            true,
+						},
+						"image_registry": schema.SingleNestedAttribute{
+							MarkdownDescription: "container registry and repository the image tag refers to, only for `docker_image`",
+							Optional:            true,
+							Attributes: map[string]schema.Attribute{
+								"container_registry": schema.StringAttribute{
+									MarkdownDescription: "name of a container registry linked to Prodvana",
+									Required:            true,
+								},
+								"repository": schema.StringAttribute{
+									MarkdownDescription: "image repository within the container registry",
+									Required:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ProtectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ProtectionResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, attr := range []string{"poll_interval", "timeout"} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attr), &value)...)
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if _, err := time.ParseDuration(value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attr), "Invalid Duration", fmt.Sprintf("%s must be a valid Go duration string, got error: %s", attr, err))
+		}
+	}
+
+	seen := map[string]bool{}
+	for idx, param := range data.Parameters {
+		paramPath := path.Root("parameters").AtListIndex(idx)
+		if param.Name.IsUnknown() || param.Type.IsUnknown() {
+			continue
+		}
+		if seen[param.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(paramPath.AtName("name"), "Duplicate Parameter", fmt.Sprintf("parameter %s is declared more than once", param.Name.ValueString()))
+		}
+		seen[param.Name.ValueString()] = true
+
+		paramType := param.Type.ValueString()
+		if param.ImageRegistry != nil && paramType != parameterTypeDockerImage {
+			resp.Diagnostics.AddAttributeError(paramPath.AtName("image_registry"), "Invalid Parameter", fmt.Sprintf("image_registry can only be set for %s parameters", parameterTypeDockerImage))
+		}
+		if param.DefaultValue.IsNull() || param.DefaultValue.IsUnknown() {
+			continue
+		}
+		switch paramType {
+		case parameterTypeInt:
+			if _, err := strconv.ParseInt(param.DefaultValue.ValueString(), 10, 64); err != nil {
+				resp.Diagnostics.AddAttributeError(paramPath.AtName("default_value"), "Invalid Parameter", fmt.Sprintf("default_value of int parameter %s must be an integer, got %q", param.Name.ValueString(), param.DefaultValue.ValueString()))
+			}
+		case parameterTypeSecret:
+			resp.Diagnostics.AddAttributeError(paramPath.AtName("default_value"), "Invalid Parameter", fmt.Sprintf("secret parameter %s cannot have a default_value", param.Name.ValueString()))
+		}
+	}
+}
+
+func (r *ProtectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.Protection
+	r.planned = clients.PlannedProtections
+}
+
+// ModifyPlan records the parameters of a protection with a pending change, so
+// that attachments planned after it are validated against them.
+func (r *ProtectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to record on destroy, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.planned == nil {
+		return
+	}
+
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() || name.IsUnknown() {
+		return
+	}
+	if req.Plan.Raw.Equal(req.State.Raw) {
+		r.planned.remove(name.ValueString())
+		return
+	}
+	var params []*protectionParameter
+	if diags := req.Plan.GetAttribute(ctx, path.Root("parameters"), &params); diags.HasError() {
+		r.planned.set(name.ValueString(), nil)
+		return
+	}
+	r.planned.set(name.ValueString(), plannedParameterDefinitions(params))
+}
+
+// plannedParameterDefinitions returns the parameter definitions attachments
+// are validated against, nil if their names, types or required flags are not
+// known until apply.
+func plannedParameterDefinitions(params []*protectionParameter) []*common_config_pb.ParameterDefinition {
+	known := make([]*protectionParameter, len(params))
+	for idx, param := range params {
+		if param.Name.IsUnknown() || param.Type.IsUnknown() || param.Required.IsUnknown() {
+			return nil
+		}
+		// defaults are not validated against attachments and may not be known yet
+		knownParam := *param
+		knownParam.DefaultValue = types.StringNull()
+		knownParam.ImageRegistry = nil
+		known[idx] = &knownParam
+	}
+	defs, err := parameterDefinitionsToProtos(known)
+	if err != nil {
+		// reported on the protection itself
+		return nil
+	}
+	return defs
+}
+
+// durationToTerraform keeps the configured spelling of a duration, e.g. `5m`
+// instead of `5m0s`, as long as it still matches what the server returned.
+func durationToTerraform(existing types.String, d *durationpb.Duration) types.String {
+	if d == nil {
+		return types.StringNull()
+	}
+	if !existing.IsNull() && !existing.IsUnknown() {
+		if parsed, err := time.ParseDuration(existing.ValueString()); err == nil && parsed == d.AsDuration() {
+			return existing
+		}
+	}
+	return types.StringValue(d.AsDuration().String())
+}
+
+func durationToProto(value types.String) (*durationpb.Duration, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return durationpb.New(d), nil
+}
+
+func parameterDefinitionsToProtos(params []*protectionParameter) ([]*common_config_pb.ParameterDefinition, error) {
+	protos := make([]*common_config_pb.ParameterDefinition, len(params))
+	for idx, param := range params {
+		def := &common_config_pb.ParameterDefinition{
+			Name:        param.Name.ValueString(),
+			Description: param.Description.ValueString(),
+			Required:    param.Required.ValueBool(),
+		}
+		switch param.Type.ValueString() {
+		case parameterTypeString:
+			def.ConfigOneof = &common_config_pb.ParameterDefinition_String_{
+				String_: &common_config_pb.StringParameterDefinition{
+					DefaultValue: param.DefaultValue.ValueString(),
+				},
+			}
+		case parameterTypeInt:
+			var defaultValue int64
+			if !param.DefaultValue.IsNull() {
+				var err error
+				defaultValue, err = strconv.ParseInt(param.DefaultValue.ValueString(), 10, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid default_value for parameter %s", param.Name.ValueString())
+				}
+			}
+			def.ConfigOneof = &common_config_pb.ParameterDefinition_Int{
+				Int: &common_config_pb.IntParameterDefinition{
+					DefaultValue: defaultValue,
+				},
+			}
+		case parameterTypeDockerImage:
+			dockerImage := &common_config_pb.DockerImageParameterDefinition{
+				DefaultTag: param.DefaultValue.ValueString(),
+			}
+			if param.ImageRegistry != nil {
+				dockerImage.ImageRegistryInfo = &common_config_pb.ImageRegistryInfo{
+					ContainerRegistry: param.ImageRegistry.ContainerRegistry.ValueString(),
+					ImageRepository:   param.ImageRegistry.Repository.ValueString(),
+				}
+			}
+			def.ConfigOneof = &common_config_pb.ParameterDefinition_DockerImage{
+				DockerImage: dockerImage,
+			}
+		case parameterTypeSecret:
+			def.ConfigOneof = &common_config_pb.ParameterDefinition_Secret{
+				Secret: &common_config_pb.SecretParameterDefinition{},
+			}
+		default:
+			return nil, errors.Errorf("invalid type %s for parameter %s, must be one of (%s)", param.Type.ValueString(), param.Name.ValueString(), strings.Join(parameterTypes, ", "))
+		}
+		protos[idx] = def
+	}
+	return protos, nil
+}
+
+// parameterDefinitionType returns the Terraform type of a parameter
+// definition, or "" for types the provider does not manage.
+func parameterDefinitionType(def *common_config_pb.ParameterDefinition) string {
+	switch def.ConfigOneof.(type) {
+	case *common_config_pb.ParameterDefinition_String_:
+		return parameterTypeString
+	case *common_config_pb.ParameterDefinition_Int:
+		return parameterTypeInt
+	case *common_config_pb.ParameterDefinition_DockerImage:
+		return parameterTypeDockerImage
+	case *common_config_pb.ParameterDefinition_Secret:
+		return parameterTypeSecret
+	}
+	return ""
+}
+
+func parameterDefinitionsFromProtos(existing []*protectionParameter, defs []*common_config_pb.ParameterDefinition) []*protectionParameter {
+	params := []*protectionParameter{}
+	for _, def := range defs {
+		paramType := parameterDefinitionType(def)
+		if paramType == "" {
+			// skip parameter types that cannot be expressed in Terraform
+			continue
+		}
+		param := &protectionParameter{
+			Name:         types.StringValue(def.Name),
+			Description:  types.StringNull(),
+			Required:     types.BoolValue(def.Required),
+			Type:         types.StringValue(paramType),
+			DefaultValue: types.StringNull(),
+		}
+		if def.Description != "" {
+			param.Description = types.StringValue(def.Description)
+		}
+		var prior *protectionParameter
+		if idx := len(params); idx < len(existing) && existing[idx].Name.ValueString() == def.Name {
+			prior = existing[idx]
+		}
+		switch paramType {
+		case parameterTypeString:
+			if v := def.GetString_().DefaultValue; v != "" {
+				param.DefaultValue = types.StringValue(v)
+			} else if prior != nil && prior.DefaultValue.ValueString() == "" {
+				param.DefaultValue = prior.DefaultValue
+			}
+		case parameterTypeInt:
+			v := def.GetInt().DefaultValue
+			if prior != nil && !prior.DefaultValue.IsNull() {
+				if parsed, err := strconv.ParseInt(prior.DefaultValue.ValueString(), 10, 64); err == nil && parsed == v {
+					param.DefaultValue = prior.DefaultValue
+					break
+				}
+			}
+			if v != 0 {
+				param.DefaultValue = types.StringValue(strconv.FormatInt(v, 10))
+			}
+		case parameterTypeDockerImage:
+			dockerImage := def.GetDockerImage()
+			if dockerImage.DefaultTag != "" {
+				param.DefaultValue = types.StringValue(dockerImage.DefaultTag)
+			}
+			if info := dockerImage.ImageRegistryInfo; info != nil {
+				param.ImageRegistry = &serviceImageRegistry{
+					ContainerRegistry: types.StringValue(info.ContainerRegistry),
+					Repository:        types.StringValue(info.ImageRepository),
+				}
+			}
+		}
+		params = append(params, param)
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+func readProtectionData(ctx context.Context, client prot_pb.ProtectionManagerClient, data *ProtectionResourceModel) error {
+	protectionResp, err := client.GetProtection(ctx, &prot_pb.GetProtectionReq{
+		Protection: data.Name.ValueString(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read protection state for %s", data.Name.ValueString())
+	}
+	configResp, err := client.GetProtectionConfig(ctx, &prot_pb.GetProtectionConfigReq{
+		Protection: data.Name.ValueString(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read protection config for %s", data.Name.ValueString())
+	}
+	config := configResp.InputConfig
+	if config == nil {
+		config = configResp.CompiledConfig
+	}
+	if config == nil {
+		return errors.Errorf("Protection %s has no config", data.Name.ValueString())
+	}
+
+	data.Id = types.StringValue(protectionResp.GetProtection().GetMeta().GetId())
+	data.Version = types.StringValue(configResp.Version)
+	data.Task = nil
+	data.KubernetesConfig = nil
+	switch exec := config.ExecConfig.(type) {
+	case *prot_pb.ProtectionConfig_TaskConfig:
+		program := exec.TaskConfig.GetProgram()
+		data.Task = &protectionTask{
+			Image:      types.StringValue(program.GetImage()),
+			Cmd:        stringsToTerraform(program.GetCmd()),
+			Entrypoint: stringsToTerraform(program.GetEntrypoint()),
+		}
+	case *prot_pb.ProtectionConfig_KubernetesConfig:
+		data.KubernetesConfig = &protectionKubernetesConfig{
+			Inlined: types.StringValue(exec.KubernetesConfig.GetInlined()),
+		}
+	}
+	data.PollInterval = durationToTerraform(data.PollInterval, config.PollInterval)
+	data.Timeout = durationToTerraform(data.Timeout, config.Timeout)
+	data.Env = optionalEnvValuesFromProtos(config.Env)
+	data.Parameters = parameterDefinitionsFromProtos(data.Parameters, config.Parameters)
+
+	return nil
+}
+
+func (r *ProtectionResource) refresh(ctx context.Context, data *ProtectionResourceModel) error {
+	return readProtectionData(ctx, r.client, data)
+}
+
+func (r *ProtectionResource) createOrUpdate(ctx context.Context, planData *ProtectionResourceModel) error {
+	config := &prot_pb.ProtectionConfig{
+		Name: planData.Name.ValueString(),
+	}
+	if planData.Task != nil {
+		config.ExecConfig = &prot_pb.ProtectionConfig_TaskConfig{
+			TaskConfig: &common_config_pb.TaskConfig{
+				Program: &common_config_pb.ProgramConfig{
+					Name:       planData.Name.ValueString(),
+					Image:      planData.Task.Image.ValueString(),
+					Cmd:        stringsFromTerraform(planData.Task.Cmd),
+					Entrypoint: stringsFromTerraform(planData.Task.Entrypoint),
+				},
+			},
+		}
+	} else if planData.KubernetesConfig != nil {
+		config.ExecConfig = &prot_pb.ProtectionConfig_KubernetesConfig{
+			KubernetesConfig: &common_config_pb.KubernetesConfig{
+				Type: common_config_pb.KubernetesConfig_KUBERNETES,
+				SourceOneof: &common_config_pb.KubernetesConfig_Inlined{
+					Inlined: planData.KubernetesConfig.Inlined.ValueString(),
+				},
+			},
+		}
+	}
+
+	var err error
+	config.PollInterval, err = durationToProto(planData.PollInterval)
+	if err != nil {
+		return errors.Wrap(err, "invalid poll_interval")
+	}
+	config.Timeout, err = durationToProto(planData.Timeout)
+	if err != nil {
+		return errors.Wrap(err, "invalid timeout")
+	}
+	config.Env, err = envValuesToProtos(planData.Env)
+	if err != nil {
+		return err
+	}
+	config.Parameters, err = parameterDefinitionsToProtos(planData.Parameters)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.ConfigureProtection(ctx, &prot_pb.ConfigureProtectionReq{
+		ProtectionConfig: config,
+		Source:           version_pb.Source_IAC,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.refresh(ctx, planData)
+}
+
+func (r *ProtectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ProtectionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.createOrUpdate(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create protection, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created protection resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProtectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ProtectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.refresh(ctx, data)
+	if err != nil {
+		// if the protection does not exist, remove the resource
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read protection state for %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProtectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData *ProtectionResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.createOrUpdate(ctx, planData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update protection, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated protection resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *ProtectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ProtectionResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the Prodvana API has no way to delete a protection
+	resp.Diagnostics.AddWarning(
+		"Protection Not Deleted",
+		fmt.Sprintf("Prodvana does not support deleting protections, %s was only removed from Terraform state.", data.Name.ValueString()),
+	)
+	tflog.Trace(ctx, "deleted protection resource")
+}
+
+func (r *ProtectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var data ProtectionResourceModel
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Name = types.StringValue(req.ID)
+	err := r.refresh(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import protection state for %s, got error: %s", req.ID, err))
+		return
+	}
+
+	// Save imported data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProtectionResource(t *testing.T) {
+	protectionName := uniqueTestName("prot-tests")
+	appName := uniqueTestName("prot-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProtectionResourceConfig(protectionName, "5m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_protection.test", "name", protectionName),
+					resource.TestCheckResourceAttrSet("prodvana_protection.test", "id"),
+					resource.TestCheckResourceAttrSet("prodvana_protection.test", "version"),
+					resource.TestCheckResourceAttr("prodvana_protection.test", "task.image", "busybox:1.36"),
+					resource.TestCheckResourceAttr("prodvana_protection.test", "timeout", "5m"),
+					resource.TestCheckResourceAttr("prodvana_protection.test", "parameters.#", "2"),
+					resource.TestCheckResourceAttr("prodvana_protection.test", "parameters.0.type", "string"),
+					resource.TestCheckResourceAttr("prodvana_protection.test", "parameters.0.required", "true"),
+					resource.TestCheckResourceAttr("prodvana_protection.test", "parameters.1.default_value", "3"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "prodvana_protection.test",
+				ImportStateId:     protectionName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// imported durations are spelled out, e.g. 5m0s
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccProtectionResourceConfig(protectionName, "10m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_protection.test", "timeout", "10m"),
+				),
+			},
+			// Attaching the protection with matching parameters
+			{
+				Config: testAccProtectionResourceConfig(protectionName, "10m") + testAccProtectionAttachmentConfig(appName, protectionName, `
+        {
+          name         = "url"
+          string_value = "https://example.com"
+        },
+        {
+          name      = "retries"
+          int_value = 5
+        },`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "protections.0.ref.name", protectionName),
+				),
+			},
+			// Declaring a parameter and passing it in the same apply
+			{
+				Config: testAccProtectionResourceConfigWithParameters(protectionName, "10m", `
+    {
+      name = "region"
+      type = "string"
+    },`) + testAccProtectionAttachmentConfig(appName, protectionName, `
+        {
+          name         = "url"
+          string_value = "https://example.com"
+        },
+        {
+          name         = "region"
+          string_value = "us-east-1"
+        },`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_protection.test", "parameters.#", "3"),
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "protections.0.ref.parameters.1.string_value", "us-east-1"),
+				),
+			},
+			// Passing a parameter with the wrong type fails at plan time
+			{
+				Config: testAccProtectionResourceConfig(protectionName, "10m") + testAccProtectionAttachmentConfig(appName, protectionName, `
+        {
+          name         = "url"
+          string_value = "https://example.com"
+        },
+        {
+          name         = "retries"
+          string_value = "five"
+        },`),
+				ExpectError: regexp.MustCompile(`of\s+type\s+int\s+but\s+a\s+string\s+value\s+was\s+set`),
+			},
+			// Passing an undeclared parameter fails at plan time
+			{
+				Config: testAccProtectionResourceConfig(protectionName, "10m") + testAccProtectionAttachmentConfig(appName, protectionName, `
+        {
+          name         = "url"
+          string_value = "https://example.com"
+        },
+        {
+          name         = "unknown"
+          string_value = "value"
+        },`),
+				ExpectError: regexp.MustCompile(`does\s+not\s+declare\s+a\s+parameter\s+named\s+unknown`),
+			},
+			// Leaving out a required parameter fails at plan time
+			{
+				Config: testAccProtectionResourceConfig(protectionName, "10m") + testAccProtectionAttachmentConfig(appName, protectionName, `
+        {
+          name      = "retries"
+          int_value = 5
+        },`),
+				ExpectError: regexp.MustCompile(`requires\s+parameter\s+url\s+to\s+be\s+set`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccProtectionResourceInvalidParameters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "prodvana_protection" "test" {
+  name = "invalid-params"
+  task = {
+    image = "busybox"
+  }
+  parameters = [
+    {
+      name          = "retries"
+      type          = "int"
+      default_value = "three"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+an\s+integer`),
+			},
+			{
+				Config: `
+resource "prodvana_protection" "test" {
+  name = "invalid-params"
+  task = {
+    image = "busybox"
+  }
+  parameters = [
+    {
+      name          = "token"
+      type          = "secret"
+      default_value = "hunter2"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`cannot\s+have\s+a\s+default_value`),
+			},
+		},
+	})
+}
+
+func testAccProtectionResourceConfig(name, timeout string) string {
+	return testAccProtectionResourceConfigWithParameters(name, timeout, "")
+}
+
+func testAccProtectionResourceConfigWithParameters(name, timeout, extraParameters string) string {
+	return fmt.Sprintf(`
+resource "prodvana_protection" "test" {
+  name = %[1]q
+  task = {
+    image = "busybox:1.36"
+    cmd   = ["sh", "-c", "wget -q -O /dev/null {{.Params.url}}"]
+  }
+  timeout = %[2]q
+  parameters = [
+    {
+      name     = "url"
+      type     = "string"
+      required = true
+    },
+    {
+      name          = "retries"
+      type          = "int"
+      default_value = "3"
+    },%[3]s
+  ]
+}
+`, name, timeout, extraParameters)
+}
+
+func testAccProtectionAttachmentConfig(app, protection, parameters string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "prodvana_release_channel" "test" {
+  name        = "test"
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+  protections = [
+    {
+      ref = {
+        name = %[2]q
+        parameters = [%[3]s
+        ]
+      }
+      deployment = {
+        enabled = true
+      }
+    },
+  ]
+  depends_on = [prodvana_protection.test]
+}
+`, testAccApplicationResourceConfig(app), protection, parameters)
+}
@@ -1,9 +1,23 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
@@ -202,3 +216,313 @@ func ProtectionAttachmentProtoToTerraform(pa *prot_pb.ProtectionAttachmentConfig
 	}
 	return attachment
 }
+
+func protectionAttachmentsToProtos(attachments []*protectionAttachment) ([]*prot_pb.ProtectionAttachmentConfig, error) {
+	protections := []*prot_pb.ProtectionAttachmentConfig{}
+	for _, protection := range attachments {
+		protoAttachment, err := protection.AsProto()
+		if err != nil {
+			return nil, err
+		}
+		protections = append(protections, protoAttachment)
+	}
+	return protections, nil
+}
+
+func attachmentProtosToTerraform(protections []*prot_pb.ProtectionAttachmentConfig) []*protectionAttachment {
+	attachments := make([]*protectionAttachment, len(protections))
+	for idx, pa := range protections {
+		attachments[idx] = ProtectionAttachmentProtoToTerraform(pa)
+	}
+	return attachments
+}
+
+// protectionAttachmentNestedObjectSchema is the schema for a single protection
+// attachment, shared by every resource that can attach protections.
+func protectionAttachmentNestedObjectSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "name of the protection",
+				Optional:            true,
+				Computed:            true,
+				Validators:          validators.DefaultNameValidators(),
+			},
+			"ref": schema.SingleNestedAttribute{
+				MarkdownDescription: "reference to a protection stored in Prodvana",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "name of the protection",
+						Required:            true,
+						Validators:          validators.DefaultNameValidators(),
+					},
+					"parameters": schema.ListNestedAttribute{
+						MarkdownDescription: "parameters to pass to the protection",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									MarkdownDescription: "name of the parameter",
+									Required:            true,
+								},
+								"string_value": schema.StringAttribute{
+									MarkdownDescription: "parameter string value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.ExactlyOneOf(
+											path.MatchRelative().AtParent().AtName("int_value"),
+											path.MatchRelative().AtParent().AtName("docker_image_tag_value"),
+											path.MatchRelative().AtParent().AtName("secret_value"),
+										),
+									},
+								},
+								"int_value": schema.Int64Attribute{
+									MarkdownDescription: "parameter int value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
+									Optional:            true,
+									Validators: []validator.Int64{
+										int64validator.ExactlyOneOf(
+											path.MatchRelative().AtParent().AtName("string_value"),
+											path.MatchRelative().AtParent().AtName("docker_image_tag_value"),
+											path.MatchRelative().AtParent().AtName("secret_value"),
+										),
+									},
+								},
+								"docker_image_tag_value": schema.StringAttribute{
+									MarkdownDescription: "parameter docker image tag value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.ExactlyOneOf(
+											path.MatchRelative().AtParent().AtName("string_value"),
+											path.MatchRelative().AtParent().AtName("int_value"),
+											path.MatchRelative().AtParent().AtName("secret_value"),
+										),
+									},
+								},
+								"secret_value": schema.SingleNestedAttribute{
+									MarkdownDescription: "parameter secret value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
+									Optional:            true,
+									Validators: []validator.Object{
+										objectvalidator.ExactlyOneOf(
+											path.MatchRelative().AtParent().AtName("string_value"),
+											path.MatchRelative().AtParent().AtName("int_value"),
+											path.MatchRelative().AtParent().AtName("docker_image_tag_value"),
+										),
+									},
+									Attributes: map[string]schema.Attribute{
+										"key": schema.StringAttribute{
+											MarkdownDescription: "Name of the secret.",
+											Required:            true,
+										},
+										"version": schema.StringAttribute{
+											MarkdownDescription: "Version of the secret",
+											Required:            true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"pre_approval": schema.SingleNestedAttribute{
+				MarkdownDescription: "pre-approval lifecycle options",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "whether to enable pre-approval lifecycle options",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+			"post_approval": schema.SingleNestedAttribute{
+				MarkdownDescription: "post-approval lifecycle options",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "whether to enable post-approval lifecycle options",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+			"deployment": schema.SingleNestedAttribute{
+				MarkdownDescription: "deployment lifecycle options",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "whether to enable deployment lifecycle options",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+			"post_deployment": schema.SingleNestedAttribute{
+				MarkdownDescription: "post-deployment lifecycle options",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "whether to enable post-deployment lifecycle options",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+					"delay_check_duration": schema.StringAttribute{
+						MarkdownDescription: "delay between the deployment completing and when this protection starts checking. A valid Go duration string, e.g. `10m` or `1h`. Defaults to `10m`",
+						Optional:            true,
+					},
+					"check_duration": schema.StringAttribute{
+						MarkdownDescription: "how long to keep checking. A valid Go duration string, e.g. `10m` or `1h`. Defaults to `10m`",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// protectionParameterValueType returns the parameter type the attachment
+// value is set for, or "" if no value is set.
+func protectionParameterValueType(param *parameterValue) string {
+	switch {
+	case !param.StringValue.IsNull():
+		return parameterTypeString
+	case !param.IntValue.IsNull():
+		return parameterTypeInt
+	case !param.DockerImageTagValue.IsNull():
+		return parameterTypeDockerImage
+	case param.SecretValue != nil:
+		return parameterTypeSecret
+	}
+	return ""
+}
+
+// plannedProtections records the parameters of prodvana_protection resources
+// with a pending change in the current plan. Attachments that depend on such a
+// protection, e.g. through prodvana_protection.<name>.name, are planned after
+// it and are validated against the planned parameters instead of the ones on
+// the server, so that a parameter can be added and set in the same apply.
+type plannedProtections struct {
+	mu     sync.Mutex
+	params map[string][]*common_config_pb.ParameterDefinition
+}
+
+func newPlannedProtections() *plannedProtections {
+	return &plannedProtections{params: map[string][]*common_config_pb.ParameterDefinition{}}
+}
+
+// set records the planned parameters of protection name, nil if they are not
+// known until apply.
+func (p *plannedProtections) set(name string, defs []*common_config_pb.ParameterDefinition) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.params[name] = defs
+}
+
+func (p *plannedProtections) remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.params, name)
+}
+
+// cache returns a parameter cache for validateProtectionAttachmentParameters
+// that starts out with the planned protections.
+func (p *plannedProtections) cache() map[string][]*common_config_pb.ParameterDefinition {
+	cache := map[string][]*common_config_pb.ParameterDefinition{}
+	if p == nil {
+		return cache
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name, defs := range p.params {
+		cache[name] = defs
+	}
+	return cache
+}
+
+// validateProtectionAttachmentParameters checks that the parameters passed to
+// every attached protection are declared by that protection with the same
+// type, and that required parameters are set. Protections that do not exist
+// yet, e.g. because they are created in the same apply, are skipped. cache
+// holds the parameters of protections already looked up, nil for protections
+// to skip, see plannedProtections.
+func validateProtectionAttachmentParameters(ctx context.Context, client prot_pb.ProtectionManagerClient, attachmentsPath path.Path, attachments []*protectionAttachment, cache map[string][]*common_config_pb.ParameterDefinition) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for idx, attachment := range attachments {
+		diags.Append(validateProtectionAttachment(ctx, client, attachmentsPath.AtListIndex(idx), attachment, cache)...)
+	}
+	return diags
+}
+
+// validateProtectionAttachment validates the parameters of a single attached
+// protection, see validateProtectionAttachmentParameters.
+func validateProtectionAttachment(ctx context.Context, client prot_pb.ProtectionManagerClient, attachmentPath path.Path, attachment *protectionAttachment, cache map[string][]*common_config_pb.ParameterDefinition) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if attachment == nil || attachment.Ref == nil || attachment.Ref.Name.IsNull() || attachment.Ref.Name.IsUnknown() {
+		return diags
+	}
+	name := attachment.Ref.Name.ValueString()
+	defs, ok := cache[name]
+	if !ok {
+		configResp, err := client.GetProtectionConfig(ctx, &prot_pb.GetProtectionConfigReq{
+			Protection: name,
+		})
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				diags.AddAttributeWarning(attachmentPath.AtName("ref"), "Unable to Validate Protection Parameters", fmt.Sprintf("Unable to read protection %s, got error: %s", name, err))
+			}
+			cache[name] = nil
+			return diags
+		}
+		config := configResp.InputConfig
+		if config == nil {
+			config = configResp.CompiledConfig
+		}
+		defs = config.GetParameters()
+		if defs == nil {
+			defs = []*common_config_pb.ParameterDefinition{}
+		}
+		cache[name] = defs
+	}
+	if defs == nil {
+		return diags
+	}
+
+	declared := map[string]*common_config_pb.ParameterDefinition{}
+	for _, def := range defs {
+		declared[def.Name] = def
+	}
+	paramsPath := attachmentPath.AtName("ref").AtName("parameters")
+	set := map[string]bool{}
+	allKnown := true
+	for paramIdx, param := range attachment.Ref.Parameters {
+		if param.Name.IsUnknown() {
+			allKnown = false
+			continue
+		}
+		set[param.Name.ValueString()] = true
+		def, ok := declared[param.Name.ValueString()]
+		if !ok {
+			diags.AddAttributeError(paramsPath.AtListIndex(paramIdx).AtName("name"), "Unknown Protection Parameter", fmt.Sprintf("Protection %s does not declare a parameter named %s", name, param.Name.ValueString()))
+			continue
+		}
+		expected := parameterDefinitionType(def)
+		actual := protectionParameterValueType(param)
+		if expected != "" && actual != "" && expected != actual {
+			diags.AddAttributeError(paramsPath.AtListIndex(paramIdx), "Invalid Protection Parameter Type", fmt.Sprintf("Parameter %s of protection %s is of type %s but a %s value was set", param.Name.ValueString(), name, expected, actual))
+		}
+	}
+	if !allKnown {
+		return diags
+	}
+	for _, def := range defs {
+		if def.Required && !set[def.Name] {
+			diags.AddAttributeError(paramsPath, "Missing Protection Parameter", fmt.Sprintf("Protection %s requires parameter %s to be set", name, def.Name))
+		}
+	}
+	return diags
+}
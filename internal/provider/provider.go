@@ -5,12 +5,15 @@ import (
 	"crypto/tls"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"google.golang.org/grpc"
@@ -26,6 +29,10 @@ type ProdvanaProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// dialOptions are appended to the default dial options, tests use this
+	// to point the provider at an in-process API server.
+	dialOptions []grpc.DialOption
 }
 
 // ProdvanaProviderModel describes the provider data model.
@@ -33,6 +40,7 @@ type ProdvanaProviderModel struct {
 	OrgSlug    types.String `tfsdk:"org_slug"`
 	ApiToken   types.String `tfsdk:"api_token"`
 	BaseDomain types.String `tfsdk:"base_domain"`
+	OnConflict types.String `tfsdk:"on_conflict"`
 }
 
 type AuthToken struct {
@@ -72,6 +80,13 @@ func (p *ProdvanaProvider) Schema(ctx context.Context, req provider.SchemaReques
 				MarkdownDescription: "(Internal Only) The base domain to connect to, the default is runprodvana.com -- only change this if you know what you're doing.",
 				Optional:            true,
 			},
+			"on_conflict": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("What to do when an application or release channel was changed outside of Terraform between plan and apply, one of (%s). `fail` (the default) stops with an error showing the change, `overwrite` replaces it with the Terraform configuration.", strings.Join(onConflictSettings, ", ")),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(onConflictSettings...),
+				},
+			},
 		},
 	}
 }
@@ -177,7 +192,9 @@ func (p *ProdvanaProvider) Configure(ctx context.Context, req provider.Configure
 	options := []grpc.DialOption{
 		grpc.WithTransportCredentials(cred),
 		grpc.WithPerRPCCredentials(AuthToken{Token: apiToken}),
+		grpc.WithUnaryInterceptor(defaultRetryPolicy.unaryClientInterceptor),
 	}
+	options = append(options, p.dialOptions...)
 
 	conn, err := grpc.Dial(domain+":443", options...)
 	if err != nil {
@@ -190,19 +207,31 @@ func (p *ProdvanaProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	resp.DataSourceData = conn
-	resp.ResourceData = conn
+	clients := NewProdvanaClients(conn)
+	clients.OnConflict = onConflictFail
+	if !data.OnConflict.IsNull() {
+		clients.OnConflict = data.OnConflict.ValueString()
+	}
+	resp.DataSourceData = clients
+	resp.ResourceData = clients
 }
 
 func (p *ProdvanaProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewApplicationResource,
 		NewReleaseChannelResource,
+		NewReleaseChannelPipelineResource,
+		NewReleaseChannelProtectionAttachmentResource,
+		NewServiceResource,
+		NewDeploymentResource,
+		NewProtectionResource,
+		NewSecretResource,
 		NewK8sRuntimeResource,
 		NewRuntimeLinkResource,
 		NewManagedK8sRuntimeResource,
 		NewContainerRegistryResource,
 		NewECRRegistryResource,
+		NewEcsRuntimeResource,
 	}
 }
 
@@ -211,6 +240,11 @@ func (p *ProdvanaProvider) DataSources(ctx context.Context) []func() datasource.
 		NewApplicationDataSource,
 		NewReleaseChannelDataSource,
 		NewK8sRuntimeDataSource,
+		NewK8sAgentManifestDataSource,
+		NewEcsRuntimeDataSource,
+		NewApplicationsDataSource,
+		NewReleaseChannelsDataSource,
+		NewRuntimesDataSource,
 	}
 }
 
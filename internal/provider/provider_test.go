@@ -1,10 +1,29 @@
 package provider
 
 import (
+	"context"
+	"os"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/fakeserver"
+)
+
+// testAccUseFakeServer is true when no Prodvana credentials are present in the
+// environment, in which case acceptance tests run against an in-process fake
+// API server instead of a real organization.
+var testAccUseFakeServer = os.Getenv("PVN_API_TOKEN") == "" && os.Getenv("PVN_APISERVER_URL") == ""
+
+var (
+	testAccFakeServerOnce sync.Once
+	testAccFakeServerInst *fakeserver.Server
+	testAccFakeServerErr  error
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -12,11 +31,140 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"prodvana": providerserver.NewProtocol6WithError(New("test")()),
+	"prodvana": func() (tfprotov6.ProviderServer, error) {
+		p := &ProdvanaProvider{version: "test"}
+		if testAccUseFakeServer {
+			srv, err := testAccFakeServer()
+			if err != nil {
+				return nil, err
+			}
+			p.dialOptions = srv.DialOptions()
+		}
+		return providerserver.NewProtocol6WithError(p)()
+	},
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	if testAccUseFakeServer {
+		if _, err := testAccFakeServer(); err != nil {
+			t.Fatalf("failed to start fake Prodvana API server: %s", err)
+		}
+		t.Setenv("PVN_ORG_SLUG", fakeserver.OrgSlug)
+		t.Setenv("PVN_API_TOKEN", fakeserver.APIToken)
+		return
+	}
+	if os.Getenv("PVN_ORG_SLUG") == "" && os.Getenv("PVN_APISERVER_URL") == "" {
+		t.Fatal("PVN_ORG_SLUG must be set for acceptance tests against a real Prodvana organization")
+	}
+	if os.Getenv("PVN_API_TOKEN") == "" {
+		t.Fatal("PVN_API_TOKEN must be set for acceptance tests against a real Prodvana organization")
+	}
+}
+
+// testAccFakeServerClients returns clients of the fake server, to change
+// objects behind the provider's back.
+func testAccFakeServerClients(t *testing.T) *ProdvanaClients {
+	srv, err := testAccFakeServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := srv.Dial(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewProdvanaClients(conn)
+}
+
+// testAccGetenv returns the value of the environment variable key, falling
+// back to fakeValue when running against the fake server and the variable
+// is unset.
+func testAccGetenv(key, fakeValue string) string {
+	value := os.Getenv(key)
+	if value == "" && testAccUseFakeServer {
+		return fakeValue
+	}
+	return value
+}
+
+// testAccFakeServer starts the shared fake server on first use and seeds it
+// with the objects the data source tests expect to already exist.
+func testAccFakeServer() (*fakeserver.Server, error) {
+	testAccFakeServerOnce.Do(func() {
+		srv, err := fakeserver.New()
+		if err != nil {
+			testAccFakeServerErr = err
+			return
+		}
+		if err := seedFakeServer(context.Background(), srv); err != nil {
+			srv.Close()
+			testAccFakeServerErr = err
+			return
+		}
+		testAccFakeServerInst = srv
+	})
+	return testAccFakeServerInst, testAccFakeServerErr
+}
+
+func seedFakeServer(ctx context.Context, srv *fakeserver.Server) error {
+	conn, err := srv.Dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// the protection the release channel protection tests attach
+	_, err = prot_pb.NewProtectionManagerClient(conn).ConfigureProtection(ctx, &prot_pb.ConfigureProtectionReq{
+		ProtectionConfig: &prot_pb.ProtectionConfig{
+			Name: "param-test",
+			ExecConfig: &prot_pb.ProtectionConfig_TaskConfig{
+				TaskConfig: &common_config_pb.TaskConfig{
+					Program: &common_config_pb.ProgramConfig{
+						Name:  "param-test",
+						Image: "busybox",
+					},
+				},
+			},
+			Parameters: []*common_config_pb.ParameterDefinition{
+				{
+					Name: "paramA",
+					ConfigOneof: &common_config_pb.ParameterDefinition_String_{
+						String_: &common_config_pb.StringParameterDefinition{},
+					},
+				},
+				{
+					Name: "paramB",
+					ConfigOneof: &common_config_pb.ParameterDefinition_Int{
+						Int: &common_config_pb.IntParameterDefinition{},
+					},
+				},
+				{
+					Name: "paramC",
+					ConfigOneof: &common_config_pb.ParameterDefinition_Secret{
+						Secret: &common_config_pb.SecretParameterDefinition{},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = app_pb.NewApplicationManagerClient(conn).ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
+		ApplicationConfig: &app_pb.ApplicationConfig{
+			Name: dataSourceAppName,
+			ReleaseChannels: []*rc_pb.ReleaseChannelConfig{
+				{
+					Name: "staging",
+					Runtimes: []*rc_pb.ReleaseChannelRuntimeConfig{
+						{
+							Runtime: fakeserver.DefaultRuntime,
+						},
+					},
+				},
+			},
+		},
+	})
+	return err
 }
@@ -12,7 +12,6 @@ import (
 	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
 	"golang.org/x/exp/maps"
-	"google.golang.org/grpc"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -319,7 +318,7 @@ func (d *ReleaseChannelDataSource) Schema(ctx context.Context, req datasource.Sc
 							Required:            true,
 						},
 						"string_value": schema.StringAttribute{
-							MarkdownDescription: "string value of the constant",
+							MarkdownDescription: "string value of the constant, null for a constant of a type the provider does not support",
 							Required:            true,
 						},
 					},
@@ -329,6 +328,14 @@ func (d *ReleaseChannelDataSource) Schema(ctx context.Context, req datasource.Sc
 				MarkdownDescription: "Disable all protections for this release channel",
 				Optional:            true,
 			},
+			"ignore_unmanaged_protections": schema.BoolAttribute{
+				MarkdownDescription: "Only used by the `prodvana_release_channel` resource, always null",
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Only used by the `prodvana_release_channel` resource, always null",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -339,18 +346,18 @@ func (d *ReleaseChannelDataSource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = rc_pb.NewReleaseChannelManagerClient(conn)
+	d.client = clients.ReleaseChannel
 }
 
 func (d *ReleaseChannelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -0,0 +1,795 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	version_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReleaseChannelPipelineResource{}
+var _ resource.ResourceWithImportState = &ReleaseChannelPipelineResource{}
+var _ resource.ResourceWithModifyPlan = &ReleaseChannelPipelineResource{}
+var _ resource.ResourceWithValidateConfig = &ReleaseChannelPipelineResource{}
+
+func NewReleaseChannelPipelineResource() resource.Resource {
+	return &ReleaseChannelPipelineResource{}
+}
+
+// ReleaseChannelPipelineResource manages an ordered list of release channels,
+// each requiring the previous one to be stable before it can be deployed.
+type ReleaseChannelPipelineResource struct {
+	client             rc_pb.ReleaseChannelManagerClient
+	protectionClient   prot_pb.ProtectionManagerClient
+	plannedProtections *plannedProtections
+}
+
+// ReleaseChannelPipelineResourceModel describes the resource data model. The
+// pipeline level settings apply to every stage that does not set its own.
+type ReleaseChannelPipelineResourceModel struct {
+	Application   types.String                     `tfsdk:"application"`
+	Id            types.String                     `tfsdk:"id"`
+	AdoptExisting types.Bool                       `tfsdk:"adopt_existing"`
+	Stages        []*releaseChannelPipelineStage   `tfsdk:"stages"`
+	Policy        *policyModel                     `tfsdk:"policy"`
+	Runtimes      []*releaseChannelPipelineRuntime `tfsdk:"runtimes"`
+
+	Protections                []*protectionAttachment `tfsdk:"protections"`
+	ConvergenceProtections     []*protectionAttachment `tfsdk:"convergence_protections"`
+	ServiceInstanceProtections []*protectionAttachment `tfsdk:"service_instance_protections"`
+
+	Constants []*constant `tfsdk:"constants"`
+}
+
+type releaseChannelPipelineStage struct {
+	Name     types.String                     `tfsdk:"name"`
+	Id       types.String                     `tfsdk:"id"`
+	Version  types.String                     `tfsdk:"version"`
+	Policy   *policyModel                     `tfsdk:"policy"`
+	Runtimes []*releaseChannelPipelineRuntime `tfsdk:"runtimes"`
+
+	ReleaseChannelStablePreconditions types.List              `tfsdk:"release_channel_stable_preconditions"`
+	ManualApprovalPreconditions       []*manualApproval       `tfsdk:"manual_approval_preconditions"`
+	SharedManualApprovalPreconditions []*sharedManualApproval `tfsdk:"shared_manual_approval_preconditions"`
+
+	Protections                []*protectionAttachment `tfsdk:"protections"`
+	ConvergenceProtections     []*protectionAttachment `tfsdk:"convergence_protections"`
+	ServiceInstanceProtections []*protectionAttachment `tfsdk:"service_instance_protections"`
+
+	Constants []*constant `tfsdk:"constants"`
+
+	DisableAllProtections types.Bool `tfsdk:"disable_all_protections"`
+}
+
+type releaseChannelPipelineRuntime struct {
+	Runtime      types.String `tfsdk:"runtime"`
+	Name         types.String `tfsdk:"name"`
+	K8sNamespace types.String `tfsdk:"k8s_namespace"`
+	EcsPrefix    types.String `tfsdk:"ecs_prefix"`
+}
+
+func (r *ReleaseChannelPipelineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_release_channel_pipeline"
+}
+
+func releaseChannelPipelineRuntimeNestedObjectSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"runtime": schema.StringAttribute{
+				MarkdownDescription: "name of the a runtime",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "optional identifier for this runtime connection within the release channel, defaults to the runtime name",
+				Optional:            true,
+			},
+			"k8s_namespace": schema.StringAttribute{
+				MarkdownDescription: "Optionally set a custom namespace. If not set, Prodvana will create and manage the namespace. If set, the namespace *must* already exist and Prodvana will not try to create or delete it. Can only be set on a Kubernetes Runtime.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("ecs_prefix")),
+				},
+			},
+			"ecs_prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix used when naming ECS resources. Can only be set on an ECS Runtime.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("k8s_namespace")),
+				},
+			},
+		},
+	}
+}
+
+func (r *ReleaseChannelPipelineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	protectionSchema := protectionAttachmentNestedObjectSchema()
+	runtimeSchema := releaseChannelPipelineRuntimeNestedObjectSchema()
+	policySchema := schema.SingleNestedAttribute{
+		MarkdownDescription: "Release Channel policy applied to all services",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"default_env": schema.MapNestedAttribute{
+				NestedObject:        envValueNestedObjectSchema(),
+				MarkdownDescription: "default environment variables for services in this Release Channel",
+				Optional:            true,
+			},
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource manages an ordered pipeline of Prodvana [Release Channels](https://docs.prodvana.io/docs/prodvana-concepts#release-channel), e.g. dev, staging and prod. Each stage is a release channel that requires the previous stage to be stable before it can be deployed. Stages can be inserted or removed without recreating the other release channels. Settings at the pipeline level apply to every stage that does not set its own.",
+		Attributes: map[string]schema.Attribute{
+			"application": schema.StringAttribute{
+				MarkdownDescription: "Name of the Application the Release Channels belong to",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Pipeline identifier, of the form `<application>/<stage>,<stage>,...`",
+				Computed:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Take over existing release channels with the same name as a stage when creating this resource or adding stages to it. By default this fails if any of those release channels already exists, so that two Terraform configurations do not overwrite each other.",
+				Optional:            true,
+			},
+			"stages": schema.ListNestedAttribute{
+				MarkdownDescription: "Release Channels in the order changes are deployed to them",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Release Channel name",
+							Required:            true,
+							Validators:          validators.DefaultNameValidators(),
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Release channel identifier",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "Current release channel version",
+							Computed:            true,
+						},
+						"policy": policySchema,
+						"runtimes": schema.ListNestedAttribute{
+							MarkdownDescription: "Runtimes of this stage, overriding the pipeline `runtimes`",
+							Optional:            true,
+							NestedObject:        runtimeSchema,
+						},
+						"release_channel_stable_preconditions": schema.ListAttribute{
+							MarkdownDescription: "Release channels that must be stable before this stage can be deployed, generated from the stage order",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"manual_approval_preconditions": schema.ListNestedAttribute{
+							MarkdownDescription: "Preconditions requiring manual approval before this stage can be deployed",
+							Optional:            true,
+							NestedObject:        manualApprovalNestedObjectSchema(),
+						},
+						"shared_manual_approval_preconditions": schema.ListNestedAttribute{
+							MarkdownDescription: "Preconditions requiring manual approval before this stage can be deployed, shared across release channels",
+							Optional:            true,
+							NestedObject:        sharedManualApprovalNestedObjectSchema(),
+						},
+						"protections": schema.ListNestedAttribute{
+							MarkdownDescription: "Protections applied to this stage, overriding the pipeline `protections`. Set to an empty list to apply none.",
+							Optional:            true,
+							NestedObject:        protectionSchema,
+						},
+						"service_instance_protections": schema.ListNestedAttribute{
+							MarkdownDescription: "Protections applied to service instances in this stage, overriding the pipeline `service_instance_protections`. Set to an empty list to apply none.",
+							Optional:            true,
+							NestedObject:        protectionSchema,
+						},
+						"convergence_protections": schema.ListNestedAttribute{
+							MarkdownDescription: "Feature Coming Soon",
+							Optional:            true,
+							NestedObject:        protectionSchema,
+						},
+						"constants": schema.ListNestedAttribute{
+							MarkdownDescription: "Constant values for this stage, overriding the pipeline `constants`",
+							Optional:            true,
+							NestedObject:        constantNestedObjectSchema(),
+						},
+						"disable_all_protections": schema.BoolAttribute{
+							MarkdownDescription: "Disable all protections for this stage",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"policy": policySchema,
+			"runtimes": schema.ListNestedAttribute{
+				MarkdownDescription: "Runtimes of every stage that does not set its own",
+				Optional:            true,
+				NestedObject:        runtimeSchema,
+			},
+			"protections": schema.ListNestedAttribute{
+				MarkdownDescription: "Protections applied to every stage that does not set its own",
+				Optional:            true,
+				NestedObject:        protectionSchema,
+			},
+			"service_instance_protections": schema.ListNestedAttribute{
+				MarkdownDescription: "Protections applied to service instances in every stage that does not set its own",
+				Optional:            true,
+				NestedObject:        protectionSchema,
+			},
+			"convergence_protections": schema.ListNestedAttribute{
+				MarkdownDescription: "Feature Coming Soon",
+				Optional:            true,
+				NestedObject:        protectionSchema,
+			},
+			"constants": schema.ListNestedAttribute{
+				MarkdownDescription: "Constant values for every stage that does not set its own",
+				Optional:            true,
+				NestedObject:        constantNestedObjectSchema(),
+			},
+		},
+	}
+}
+
+func (r *ReleaseChannelPipelineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.ReleaseChannel
+	r.protectionClient = clients.Protection
+	r.plannedProtections = clients.PlannedProtections
+}
+
+func (r *ReleaseChannelPipelineResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ReleaseChannelPipelineResourceModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		// parts of the stages are unknown until apply, leave them to the API
+		return
+	}
+
+	names := map[string]bool{}
+	for idx, stage := range data.Stages {
+		if stage.Name.IsUnknown() {
+			continue
+		}
+		if names[stage.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(path.Root("stages").AtListIndex(idx).AtName("name"), "Duplicate Stage", fmt.Sprintf("Stage %s appears more than once in the pipeline", stage.Name.ValueString()))
+		}
+		names[stage.Name.ValueString()] = true
+		if stage.Runtimes == nil && data.Runtimes == nil {
+			resp.Diagnostics.AddAttributeError(path.Root("stages").AtListIndex(idx).AtName("runtimes"), "Missing Runtimes", fmt.Sprintf("Stage %s needs `runtimes`, either on the stage or on the pipeline", stage.Name.ValueString()))
+		}
+	}
+}
+
+// ModifyPlan fills in what is known about the stages before apply, so that
+// only the stages whose release channel changes show a new version, and
+// validates the parameters passed to attached protections.
+func (r *ReleaseChannelPipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planData ReleaseChannelPipelineResourceModel
+	if diags := req.Plan.Get(ctx, &planData); diags.HasError() {
+		// parts of the stages are unknown until apply, leave them to the apply
+		return
+	}
+	var stateData *ReleaseChannelPipelineResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	planData.Id = pipelineId(&planData)
+	fillPipelineProtectionNames(&planData)
+	stateStages := map[string]int{}
+	if stateData != nil {
+		for idx, stage := range stateData.Stages {
+			stateStages[stage.Name.ValueString()] = idx
+		}
+	}
+	for idx, stage := range planData.Stages {
+		stage.ReleaseChannelStablePreconditions = pipelineStablePreconditions(&planData, idx)
+		stage.Id = types.StringUnknown()
+		stage.Version = types.StringUnknown()
+		stateIdx, ok := stateStages[stage.Name.ValueString()]
+		if !ok || stage.Name.IsUnknown() {
+			continue
+		}
+		stateStage := stateData.Stages[stateIdx]
+		stage.Id = stateStage.Id
+		unchanged, err := pipelineStageUnchanged(&planData, idx, stateData, stateIdx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("stages").AtListIndex(idx), "Invalid Stage", err.Error())
+			return
+		}
+		if unchanged {
+			stage.Version = stateStage.Version
+		}
+	}
+
+	if r.protectionClient != nil {
+		cache := r.plannedProtections.cache()
+		validate := func(attachmentsPath path.Path, attachments []*protectionAttachment) {
+			resp.Diagnostics.Append(validateProtectionAttachmentParameters(ctx, r.protectionClient, attachmentsPath, attachments, cache)...)
+		}
+		validate(path.Root("protections"), planData.Protections)
+		validate(path.Root("convergence_protections"), planData.ConvergenceProtections)
+		validate(path.Root("service_instance_protections"), planData.ServiceInstanceProtections)
+		for idx, stage := range planData.Stages {
+			stagePath := path.Root("stages").AtListIndex(idx)
+			validate(stagePath.AtName("protections"), stage.Protections)
+			validate(stagePath.AtName("convergence_protections"), stage.ConvergenceProtections)
+			validate(stagePath.AtName("service_instance_protections"), stage.ServiceInstanceProtections)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &planData)...)
+}
+
+// pipelineId identifies the pipeline by its application and stages, in the
+// format accepted by import.
+func pipelineId(data *ReleaseChannelPipelineResourceModel) types.String {
+	if data.Application.IsUnknown() {
+		return types.StringUnknown()
+	}
+	names := make([]string, len(data.Stages))
+	for idx, stage := range data.Stages {
+		if stage.Name.IsUnknown() {
+			return types.StringUnknown()
+		}
+		names[idx] = stage.Name.ValueString()
+	}
+	return types.StringValue(data.Application.ValueString() + "/" + strings.Join(names, ","))
+}
+
+// pipelineStablePreconditions lists the release channels that must be stable
+// before the stage at idx can be deployed, which is the stage before it.
+func pipelineStablePreconditions(data *ReleaseChannelPipelineResourceModel, idx int) types.List {
+	if idx == 0 {
+		return types.ListValueMust(types.StringType, []attr.Value{})
+	}
+	return types.ListValueMust(types.StringType, []attr.Value{data.Stages[idx-1].Name})
+}
+
+// fillPipelineProtectionNames defaults the name of protection attachments to
+// the protection they reference, like the API does.
+func fillPipelineProtectionNames(data *ReleaseChannelPipelineResourceModel) {
+	attachmentLists := [][]*protectionAttachment{data.Protections, data.ConvergenceProtections, data.ServiceInstanceProtections}
+	for _, stage := range data.Stages {
+		attachmentLists = append(attachmentLists, stage.Protections, stage.ConvergenceProtections, stage.ServiceInstanceProtections)
+	}
+	for _, attachments := range attachmentLists {
+		for _, pa := range attachments {
+			if pa.Ref != nil && (pa.Name.IsNull() || pa.Name.IsUnknown()) && !pa.Ref.Name.IsUnknown() {
+				pa.Name = pa.Ref.Name
+			}
+		}
+	}
+}
+
+// pipelineStageReleaseChannel resolves the stage at idx into the release
+// channel it materializes, applying the pipeline settings the stage does not
+// override.
+func pipelineStageReleaseChannel(data *ReleaseChannelPipelineResourceModel, idx int) *ReleaseChannelResourceModel {
+	stage := data.Stages[idx]
+	rc := &ReleaseChannelResourceModel{
+		Name:                              stage.Name,
+		Application:                       data.Application,
+		Policy:                            stage.Policy,
+		ManualApprovalPreconditions:       stage.ManualApprovalPreconditions,
+		SharedManualApprovalPreconditions: stage.SharedManualApprovalPreconditions,
+		Protections:                       stage.Protections,
+		ConvergenceProtections:            stage.ConvergenceProtections,
+		ServiceInstanceProtections:        stage.ServiceInstanceProtections,
+		Constants:                         stage.Constants,
+		DisableAllProtections:             stage.DisableAllProtections,
+	}
+	if rc.Policy == nil {
+		rc.Policy = data.Policy
+	}
+	if rc.Protections == nil {
+		rc.Protections = data.Protections
+	}
+	if rc.ConvergenceProtections == nil {
+		rc.ConvergenceProtections = data.ConvergenceProtections
+	}
+	if rc.ServiceInstanceProtections == nil {
+		rc.ServiceInstanceProtections = data.ServiceInstanceProtections
+	}
+	if rc.Constants == nil {
+		rc.Constants = data.Constants
+	}
+	runtimes := stage.Runtimes
+	if runtimes == nil {
+		runtimes = data.Runtimes
+	}
+	for _, rt := range runtimes {
+		rcRuntime := &releaseChannelRuntimeConfig{
+			Runtime:      rt.Runtime,
+			Name:         rt.Name,
+			K8sNamespace: rt.K8sNamespace,
+			EcsPrefix:    rt.EcsPrefix,
+		}
+		// unknown leaves the runtime capability to the API
+		if rcRuntime.K8sNamespace.IsNull() {
+			rcRuntime.K8sNamespace = types.StringUnknown()
+		}
+		if rcRuntime.EcsPrefix.IsNull() {
+			rcRuntime.EcsPrefix = types.StringUnknown()
+		}
+		rc.Runtimes = append(rc.Runtimes, rcRuntime)
+	}
+	if idx > 0 {
+		rc.ReleaseChannelStablePreconditions = []*releaseChannelStable{
+			{ReleaseChannel: data.Stages[idx-1].Name},
+		}
+	}
+	return rc
+}
+
+// pipelineStageFromReleaseChannel describes a release channel read from the
+// API as a stage, with every setting on the stage itself.
+func pipelineStageFromReleaseChannel(rc *ReleaseChannelResourceModel) *releaseChannelPipelineStage {
+	stage := &releaseChannelPipelineStage{
+		Name:                              rc.Name,
+		Id:                                rc.Id,
+		Version:                           rc.Version,
+		Policy:                            rc.Policy,
+		ManualApprovalPreconditions:       rc.ManualApprovalPreconditions,
+		SharedManualApprovalPreconditions: rc.SharedManualApprovalPreconditions,
+		Protections:                       rc.Protections,
+		ConvergenceProtections:            rc.ConvergenceProtections,
+		ServiceInstanceProtections:        rc.ServiceInstanceProtections,
+		DisableAllProtections:             rc.DisableAllProtections,
+	}
+	if len(rc.Constants) > 0 {
+		stage.Constants = rc.Constants
+	}
+	for _, rt := range rc.Runtimes {
+		stage.Runtimes = append(stage.Runtimes, &releaseChannelPipelineRuntime{
+			Runtime:      rt.Runtime,
+			Name:         rt.Name,
+			K8sNamespace: rt.K8sNamespace,
+			EcsPrefix:    rt.EcsPrefix,
+		})
+	}
+	stable := []attr.Value{}
+	for _, precondition := range rc.ReleaseChannelStablePreconditions {
+		stable = append(stable, precondition.ReleaseChannel)
+	}
+	stage.ReleaseChannelStablePreconditions = types.ListValueMust(types.StringType, stable)
+	return stage
+}
+
+// pipelineStageUnchanged reports whether the stage at idx materializes the
+// same release channel as the stage at stateIdx of the prior state.
+func pipelineStageUnchanged(planData *ReleaseChannelPipelineResourceModel, idx int, stateData *ReleaseChannelPipelineResourceModel, stateIdx int) (bool, error) {
+	planConfig, err := releaseChannelConfigFromModel(pipelineStageReleaseChannel(planData, idx))
+	if err != nil {
+		return false, err
+	}
+	stateConfig, err := releaseChannelConfigFromModel(pipelineStageReleaseChannel(stateData, stateIdx))
+	if err != nil {
+		return false, err
+	}
+	return proto.Equal(normalizeReleaseChannelConfig(planConfig), normalizeReleaseChannelConfig(stateConfig)), nil
+}
+
+// readPipelineStages refreshes the stages from their release channels. Stages
+// whose release channel no longer exists are dropped, and stages whose release
+// channel was changed outside of this resource take the settings read from the
+// API so that the difference shows in the plan.
+func readPipelineStages(ctx context.Context, client rc_pb.ReleaseChannelManagerClient, data *ReleaseChannelPipelineResourceModel) error {
+	stages := []*releaseChannelPipelineStage{}
+	for idx, stage := range data.Stages {
+		rc := &ReleaseChannelResourceModel{
+			Name:        stage.Name,
+			Application: data.Application,
+		}
+		err := readReleaseChannelData(ctx, client, rc)
+		if err != nil {
+			if status.Code(errors.Cause(err)) == codes.NotFound {
+				continue
+			}
+			return err
+		}
+		desired, err := releaseChannelConfigFromModel(pipelineStageReleaseChannel(data, idx))
+		if err != nil {
+			return err
+		}
+		actual, err := releaseChannelConfigFromModel(rc)
+		if err != nil {
+			return err
+		}
+		if proto.Equal(normalizeReleaseChannelConfig(desired), normalizeReleaseChannelConfig(actual)) {
+			stage.Id = rc.Id
+			stage.Version = rc.Version
+			stage.ReleaseChannelStablePreconditions = pipelineStablePreconditions(data, idx)
+			stages = append(stages, stage)
+		} else {
+			stages = append(stages, pipelineStageFromReleaseChannel(rc))
+		}
+	}
+	data.Stages = stages
+	data.Id = pipelineId(data)
+	return nil
+}
+
+// configurePipelineStage materializes the stage at idx through
+// ConfigureReleaseChannel and records the resulting release channel.
+func (r *ReleaseChannelPipelineResource) configurePipelineStage(ctx context.Context, data *ReleaseChannelPipelineResourceModel, idx int) error {
+	stage := data.Stages[idx]
+	rc := pipelineStageReleaseChannel(data, idx)
+	config, err := releaseChannelConfigFromModel(rc)
+	if err != nil {
+		return errors.Wrapf(err, "Invalid stage %s", stage.Name.ValueString())
+	}
+	_, err = r.client.ConfigureReleaseChannel(ctx, &rc_pb.ConfigureReleaseChannelReq{
+		ReleaseChannel: config,
+		Application:    data.Application.ValueString(),
+		Source:         version_pb.Source_IAC,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to configure release channel %s", stage.Name.ValueString())
+	}
+	getRcResp, err := r.client.GetReleaseChannel(ctx, &rc_pb.GetReleaseChannelReq{
+		Application:    data.Application.ValueString(),
+		ReleaseChannel: stage.Name.ValueString(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read release channel state for %s", stage.Name.ValueString())
+	}
+	stage.Id = types.StringValue(getRcResp.ReleaseChannel.Meta.Id)
+	stage.Version = types.StringValue(getRcResp.ReleaseChannel.Meta.Version)
+	stage.ReleaseChannelStablePreconditions = pipelineStablePreconditions(data, idx)
+	return nil
+}
+
+// checkPipelineStagesNotExist fails if any of the given stages would take over
+// an existing release channel, unless adopt_existing is set. It runs before any
+// stage is configured, so that a conflict leaves everything untouched.
+func (r *ReleaseChannelPipelineResource) checkPipelineStagesNotExist(ctx context.Context, data *ReleaseChannelPipelineResourceModel, stages []*releaseChannelPipelineStage) error {
+	if data.AdoptExisting.ValueBool() {
+		return nil
+	}
+	for _, stage := range stages {
+		_, err := r.client.GetReleaseChannel(ctx, &rc_pb.GetReleaseChannelReq{
+			Application:    data.Application.ValueString(),
+			ReleaseChannel: stage.Name.ValueString(),
+		})
+		if err := checkNotExists("release channel", stage.Name.ValueString(), "", err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReleaseChannelPipelineResource) deletePipelineStage(ctx context.Context, application string, stage *releaseChannelPipelineStage) error {
+	_, err := r.client.DeleteReleaseChannel(ctx, &rc_pb.DeleteReleaseChannelReq{
+		Application:    application,
+		ReleaseChannel: stage.Name.ValueString(),
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return errors.Wrapf(err, "Unable to delete release channel %s", stage.Name.ValueString())
+	}
+	return nil
+}
+
+func (r *ReleaseChannelPipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ReleaseChannelPipelineResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fillPipelineProtectionNames(data)
+	if err := r.checkPipelineStagesNotExist(ctx, data, data.Stages); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create release channel pipeline, got error: %s", err))
+		return
+	}
+	// stages are created in order, so that every stage can refer to the one
+	// before it
+	for idx := range data.Stages {
+		if err := r.configurePipelineStage(ctx, data, idx); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create release channel pipeline, got error: %s", err))
+			// keep the stages created so far in state, so they are cleaned up
+			data.Stages = data.Stages[:idx]
+			data.Id = pipelineId(data)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+	data.Id = pipelineId(data)
+
+	tflog.Trace(ctx, "created release channel pipeline resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReleaseChannelPipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ReleaseChannelPipelineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := readPipelineStages(ctx, r.client, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read release channel pipeline state for %s, got error: %s", data.Application.ValueString(), err))
+		return
+	}
+	// if none of the release channels exist, remove the resource
+	if len(data.Stages) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReleaseChannelPipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData *ReleaseChannelPipelineResourceModel
+	var stateData *ReleaseChannelPipelineResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fillPipelineProtectionNames(planData)
+	stateStages := map[string]int{}
+	for idx, stage := range stateData.Stages {
+		stateStages[stage.Name.ValueString()] = idx
+	}
+	var addedStages []*releaseChannelPipelineStage
+	for _, stage := range planData.Stages {
+		if _, ok := stateStages[stage.Name.ValueString()]; !ok {
+			addedStages = append(addedStages, stage)
+		}
+	}
+	if err := r.checkPipelineStagesNotExist(ctx, planData, addedStages); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update release channel pipeline, got error: %s", err))
+		return
+	}
+	planStages := map[string]bool{}
+	for idx, stage := range planData.Stages {
+		planStages[stage.Name.ValueString()] = true
+		if stateIdx, ok := stateStages[stage.Name.ValueString()]; ok {
+			unchanged, err := pipelineStageUnchanged(planData, idx, stateData, stateIdx)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update release channel pipeline, got error: %s", err))
+				return
+			}
+			if unchanged {
+				stage.Id = stateData.Stages[stateIdx].Id
+				stage.Version = stateData.Stages[stateIdx].Version
+				stage.ReleaseChannelStablePreconditions = pipelineStablePreconditions(planData, idx)
+				continue
+			}
+		}
+		if err := r.configurePipelineStage(ctx, planData, idx); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update release channel pipeline, got error: %s", err))
+			return
+		}
+	}
+	// removed stages go last, once no remaining stage refers to them
+	for idx := len(stateData.Stages) - 1; idx >= 0; idx-- {
+		stage := stateData.Stages[idx]
+		if planStages[stage.Name.ValueString()] {
+			continue
+		}
+		if err := r.deletePipelineStage(ctx, stateData.Application.ValueString(), stage); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update release channel pipeline, got error: %s", err))
+			return
+		}
+	}
+	planData.Id = pipelineId(planData)
+
+	tflog.Trace(ctx, "updated release channel pipeline resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *ReleaseChannelPipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ReleaseChannelPipelineResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// later stages refer to earlier ones, delete them first
+	for idx := len(data.Stages) - 1; idx >= 0; idx-- {
+		if err := r.deletePipelineStage(ctx, data.Application.ValueString(), data.Stages[idx]); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete release channel pipeline, got error: %s", err))
+			return
+		}
+	}
+	tflog.Trace(ctx, "deleted release channel pipeline resource")
+}
+
+func (r *ReleaseChannelPipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// req.ID is of the form <application>/<stage>,<stage>,...
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[1] == "" {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import release channel pipeline, got error: invalid id %s, expected <application>/<stage>,<stage>,...", req.ID))
+		return
+	}
+
+	data := ReleaseChannelPipelineResourceModel{
+		Application: types.StringValue(parts[0]),
+	}
+	for _, name := range strings.Split(parts[1], ",") {
+		rc := &ReleaseChannelResourceModel{
+			Name:        types.StringValue(name),
+			Application: data.Application,
+		}
+		err := readReleaseChannelData(ctx, r.client, rc)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import release channel pipeline state for %s, got error: %s", req.ID, err))
+			return
+		}
+		data.Stages = append(data.Stages, pipelineStageFromReleaseChannel(rc))
+	}
+	data.Id = pipelineId(&data)
+
+	// Save imported data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testCheckPipelineStageUnchanged records the id and version of a stage on
+// its first call and checks that later calls see the same ones.
+func testCheckPipelineStageUnchanged(name string) func(index int) resource.TestCheckFunc {
+	var id, version string
+	return func(index int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			attrs := s.RootModule().Resources["prodvana_release_channel_pipeline.test"].Primary.Attributes
+			if attrs[fmt.Sprintf("stages.%d.name", index)] != name {
+				return fmt.Errorf("expected stage %d to be %s, got %s", index, name, attrs[fmt.Sprintf("stages.%d.name", index)])
+			}
+			stageId := attrs[fmt.Sprintf("stages.%d.id", index)]
+			stageVersion := attrs[fmt.Sprintf("stages.%d.version", index)]
+			if id == "" {
+				id, version = stageId, stageVersion
+				return nil
+			}
+			if stageId != id || stageVersion != version {
+				return fmt.Errorf("expected stage %s to stay at %s/%s, got %s/%s", name, id, version, stageId, stageVersion)
+			}
+			return nil
+		}
+	}
+}
+
+func TestAccReleaseChannelPipelineResource(t *testing.T) {
+	appName := uniqueTestName("rc-pipeline-tests")
+	dev := testCheckPipelineStageUnchanged("dev")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccReleaseChannelPipelineResourceConfig(appName, "dev", "prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "id", appName+"/dev,prod"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.#", "2"),
+					resource.TestCheckResourceAttrSet("prodvana_release_channel_pipeline.test", "stages.0.id"),
+					resource.TestCheckResourceAttrSet("prodvana_release_channel_pipeline.test", "stages.0.version"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.0.release_channel_stable_preconditions.#", "0"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.1.release_channel_stable_preconditions.#", "1"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.1.release_channel_stable_preconditions.0", "dev"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.1.manual_approval_preconditions.0.name", "prod-approval"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.1.constants.0.string_value", "prod"),
+					dev(0),
+				),
+			},
+			// ImportState testing, settings read back land on the stages
+			{
+				ResourceName:      "prodvana_release_channel_pipeline.test",
+				ImportStateId:     appName + "/dev,prod",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"runtimes",
+					"constants",
+					"stages.0.runtimes",
+					"stages.0.constants",
+					"stages.1.runtimes",
+				},
+			},
+			// inserting a stage only touches the stages around it
+			{
+				Config: testAccReleaseChannelPipelineResourceConfig(appName, "dev", "staging", "prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "id", appName+"/dev,staging,prod"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.1.release_channel_stable_preconditions.0", "dev"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.2.release_channel_stable_preconditions.0", "staging"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channel.prod", "release_channel_stable_preconditions.0.release_channel", "staging"),
+					dev(0),
+				),
+			},
+			// removing a stage rewires the stage after it
+			{
+				Config: testAccReleaseChannelPipelineResourceConfig(appName, "staging", "prod"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "id", appName+"/staging,prod"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.0.release_channel_stable_preconditions.#", "0"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "stages.1.release_channel_stable_preconditions.0", "staging"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.all", "release_channels.#", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccReleaseChannelPipelineResourceExistingStage(t *testing.T) {
+	appName := uniqueTestName("rc-pipeline-existing")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// creating a pipeline over an existing release channel fails
+			{
+				Config:      testAccReleaseChannelPipelineResourceExistingConfig(appName, "dev", "prod"),
+				ExpectError: regexp.MustCompile(`release\s+channel\s+dev\s+already\s+exists`),
+			},
+			// and does not configure any of the other stages either
+			{
+				Config: testAccReleaseChannelPipelineResourceExistingConfig(appName, "staging"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel_pipeline.test", "id", appName+"/staging"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.all", "release_channels.#", "2"),
+				),
+			},
+			// adding an existing release channel as a stage fails too
+			{
+				Config:      testAccReleaseChannelPipelineResourceExistingConfig(appName, "staging", "dev"),
+				ExpectError: regexp.MustCompile(`release\s+channel\s+dev\s+already\s+exists`),
+			},
+		},
+	})
+}
+
+func testAccReleaseChannelPipelineResourceExistingConfig(app string, stages ...string) string {
+	stageConfigs := make([]string, len(stages))
+	for idx, stage := range stages {
+		stageConfigs[idx] = fmt.Sprintf(`
+    {
+      name = %q
+    },`, stage)
+	}
+	return fmt.Sprintf(`
+%[1]s
+
+resource "prodvana_release_channel_pipeline" "test" {
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+  stages = [%[2]s
+  ]
+  depends_on = [prodvana_release_channel.dev]
+}
+
+data "prodvana_release_channels" "all" {
+  application = prodvana_application.app.name
+  depends_on  = [prodvana_release_channel_pipeline.test]
+}
+`, testAccReleaseChannelResourceConfig("dev", app, nil), strings.Join(stageConfigs, ""))
+}
+
+func testAccReleaseChannelPipelineResourceConfig(app string, stages ...string) string {
+	stageConfigs := make([]string, len(stages))
+	for idx, stage := range stages {
+		switch stage {
+		case "prod":
+			stageConfigs[idx] = `
+    {
+      name = "prod"
+      manual_approval_preconditions = [
+        {
+          name = "prod-approval"
+        },
+      ]
+      constants = [
+        {
+          name         = "tier"
+          string_value = "prod"
+        },
+      ]
+    },`
+		default:
+			stageConfigs[idx] = fmt.Sprintf(`
+    {
+      name = %q
+    },`, stage)
+		}
+	}
+	prodCheck := ""
+	for _, stage := range stages {
+		if stage == "prod" {
+			prodCheck = `
+data "prodvana_release_channel" "prod" {
+  name        = "prod"
+  application = prodvana_application.app.name
+  depends_on  = [prodvana_release_channel_pipeline.test]
+}
+`
+		}
+	}
+	return fmt.Sprintf(`
+%[1]s
+
+resource "prodvana_release_channel_pipeline" "test" {
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+  constants = [
+    {
+      name         = "tier"
+      string_value = "pre-prod"
+    },
+  ]
+  stages = [%[2]s
+  ]
+}
+%[3]s
+data "prodvana_release_channels" "all" {
+  application = prodvana_application.app.name
+  depends_on  = [prodvana_release_channel_pipeline.test]
+}
+`, testAccApplicationResourceConfig(app), strings.Join(stageConfigs, ""), prodCheck)
+}
@@ -0,0 +1,463 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	app_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/application"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	version_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReleaseChannelProtectionAttachmentResource{}
+var _ resource.ResourceWithImportState = &ReleaseChannelProtectionAttachmentResource{}
+var _ resource.ResourceWithModifyPlan = &ReleaseChannelProtectionAttachmentResource{}
+
+// the release channel protection lists an attachment can be added to
+var releaseChannelAttachmentLists = []string{"protections", "convergence_protections", "service_instance_protections"}
+
+func NewReleaseChannelProtectionAttachmentResource() resource.Resource {
+	return &ReleaseChannelProtectionAttachmentResource{}
+}
+
+// ReleaseChannelProtectionAttachmentResource manages a single protection
+// attachment on a release channel that is otherwise managed elsewhere.
+type ReleaseChannelProtectionAttachmentResource struct {
+	client             rc_pb.ReleaseChannelManagerClient
+	appClient          app_pb.ApplicationManagerClient
+	protectionClient   prot_pb.ProtectionManagerClient
+	plannedProtections *plannedProtections
+	locks              *keyedMutex
+}
+
+// ReleaseChannelProtectionAttachmentResourceModel describes the resource data model.
+type ReleaseChannelProtectionAttachmentResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Application    types.String `tfsdk:"application"`
+	ReleaseChannel types.String `tfsdk:"release_channel"`
+	AttachTo       types.String `tfsdk:"attach_to"`
+
+	Name types.String         `tfsdk:"name"`
+	Ref  *protectionReference `tfsdk:"ref"`
+
+	PreApproval    *preApproval    `tfsdk:"pre_approval"`
+	PostApproval   *postApproval   `tfsdk:"post_approval"`
+	Deployment     *deployment     `tfsdk:"deployment"`
+	PostDeployment *postDeployment `tfsdk:"post_deployment"`
+}
+
+func (data *ReleaseChannelProtectionAttachmentResourceModel) attachment() *protectionAttachment {
+	return &protectionAttachment{
+		Name:           data.Name,
+		Ref:            data.Ref,
+		PreApproval:    data.PreApproval,
+		PostApproval:   data.PostApproval,
+		Deployment:     data.Deployment,
+		PostDeployment: data.PostDeployment,
+	}
+}
+
+func (data *ReleaseChannelProtectionAttachmentResourceModel) setAttachment(pa *protectionAttachment) {
+	data.Name = pa.Name
+	data.Ref = pa.Ref
+	data.PreApproval = pa.PreApproval
+	data.PostApproval = pa.PostApproval
+	data.Deployment = pa.Deployment
+	data.PostDeployment = pa.PostDeployment
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_release_channel_protection_attachment"
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Protection attachment identifier, of the form `<application>/<release channel>/<attach_to>/<name>`",
+		},
+		"application": schema.StringAttribute{
+			MarkdownDescription: "Name of the Application the Release Channel belongs to",
+			Required:            true,
+			Validators:          validators.DefaultNameValidators(),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"release_channel": schema.StringAttribute{
+			MarkdownDescription: "Name of the Release Channel to attach the protection to",
+			Required:            true,
+			Validators:          validators.DefaultNameValidators(),
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"attach_to": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Release Channel protection list the protection is attached to, one of (%s). Defaults to `protections`.", strings.Join(releaseChannelAttachmentLists, ", ")),
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString("protections"),
+			Validators: []validator.String{
+				stringvalidator.OneOf(releaseChannelAttachmentLists...),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+	}
+	for name, attr := range protectionAttachmentNestedObjectSchema().Attributes {
+		attributes[name] = attr
+	}
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "name of the protection attachment, unique within the Release Channel protection list. Defaults to the name of the referenced protection.",
+		Optional:            true,
+		Computed:            true,
+		Validators:          validators.DefaultNameValidators(),
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource attaches a single protection to a Prodvana [Release Channel](https://docs.prodvana.io/docs/prodvana-concepts#release-channel) managed elsewhere, e.g. to add a mandatory protection to release channels owned by another team. The other attachments of the Release Channel are left untouched. If the Release Channel is managed by `prodvana_release_channel`, set `ignore_unmanaged_protections` on it so it does not remove this attachment.",
+		Attributes:          attributes,
+	}
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.ReleaseChannel
+	r.appClient = clients.Application
+	r.protectionClient = clients.Protection
+	r.plannedProtections = clients.PlannedProtections
+	r.locks = clients.ReleaseChannelLocks
+}
+
+// ModifyPlan defaults the attachment name to the referenced protection, like
+// the API does, and validates the parameters passed to the protection.
+func (r *ReleaseChannelProtectionAttachmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ReleaseChannelProtectionAttachmentResourceModel
+	if diags := req.Plan.Get(ctx, &data); diags.HasError() {
+		// parts of the attachment are unknown until apply, leave them to the API
+		return
+	}
+
+	if data.Name.IsUnknown() && data.Ref != nil && !data.Ref.Name.IsUnknown() {
+		data.Name = data.Ref.Name
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), data.Name)...)
+	}
+	if r.protectionClient != nil {
+		cache := r.plannedProtections.cache()
+		resp.Diagnostics.Append(validateProtectionAttachment(ctx, r.protectionClient, path.Empty(), data.attachment(), cache)...)
+	}
+}
+
+// releaseChannelAttachments returns the protection list of config named by
+// attachTo.
+func releaseChannelAttachments(config *rc_pb.ReleaseChannelConfig, attachTo string) *[]*prot_pb.ProtectionAttachmentConfig {
+	switch attachTo {
+	case "convergence_protections":
+		return &config.ConvergenceProtections
+	case "service_instance_protections":
+		return &config.ServiceInstanceProtections
+	default:
+		return &config.Protections
+	}
+}
+
+// findProtectionAttachment returns the index of the attachment named name, or
+// -1 when there is none.
+func findProtectionAttachment(attachments []*prot_pb.ProtectionAttachmentConfig, name string) int {
+	for idx, pa := range attachments {
+		paName := pa.Name
+		if paName == "" && pa.Ref != nil {
+			paName = pa.Ref.Name
+		}
+		if paName == name {
+			return idx
+		}
+	}
+	return -1
+}
+
+// updateReleaseChannelAttachments reads the release channel config, lets
+// update change one of its protection lists and writes the config back.
+//
+// Attachments to the same release channel are applied in parallel, so the
+// read and write are serialized by the provider. The config is written through
+// the application, whose configure call takes a base version, so that a
+// change made elsewhere in between is not overwritten either.
+func (r *ReleaseChannelProtectionAttachmentResource) updateReleaseChannelAttachments(ctx context.Context, data *ReleaseChannelProtectionAttachmentResourceModel, update func([]*prot_pb.ProtectionAttachmentConfig) ([]*prot_pb.ProtectionAttachmentConfig, error)) error {
+	if r.locks != nil {
+		unlock := r.locks.Lock(data.Application.ValueString() + "/" + data.ReleaseChannel.ValueString())
+		defer unlock()
+	}
+
+	for attempt := 0; ; attempt++ {
+		getAppResp, err := r.appClient.GetApplication(ctx, &app_pb.GetApplicationReq{
+			Application: data.Application.ValueString(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Unable to read release channel state for %s", data.ReleaseChannel.ValueString())
+		}
+		appConfig := getAppResp.GetApplication().GetConfig()
+		var config *rc_pb.ReleaseChannelConfig
+		for _, rc := range appConfig.GetReleaseChannels() {
+			if rc.Name == data.ReleaseChannel.ValueString() {
+				config = rc
+				break
+			}
+		}
+		if config == nil {
+			return status.Errorf(codes.NotFound, "release channel %s not found in application %s", data.ReleaseChannel.ValueString(), data.Application.ValueString())
+		}
+		attachments := releaseChannelAttachments(config, data.AttachTo.ValueString())
+		updated, err := update(*attachments)
+		if err != nil {
+			return err
+		}
+		*attachments = updated
+		_, err = r.appClient.ConfigureApplication(ctx, &app_pb.ConfigureApplicationReq{
+			ApplicationConfig: appConfig,
+			Source:            version_pb.Source_IAC,
+			BaseVersion:       getAppResp.GetApplication().GetMeta().GetVersion(),
+		})
+		if err != nil {
+			// changed outside of Terraform between reading and configuring it, apply the change again
+			if isConflict(err) && attempt == 0 {
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) refresh(ctx context.Context, data *ReleaseChannelProtectionAttachmentResourceModel) (bool, error) {
+	getRcResp, err := r.client.GetReleaseChannel(ctx, &rc_pb.GetReleaseChannelReq{
+		Application:    data.Application.ValueString(),
+		ReleaseChannel: data.ReleaseChannel.ValueString(),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "Unable to read release channel state for %s", data.ReleaseChannel.ValueString())
+	}
+	attachments := *releaseChannelAttachments(getRcResp.ReleaseChannel.Config, data.AttachTo.ValueString())
+	idx := findProtectionAttachment(attachments, data.Name.ValueString())
+	if idx < 0 {
+		return false, nil
+	}
+	name := data.Name
+	attachment := ProtectionAttachmentProtoToTerraform(attachments[idx])
+	// keep parameters left out of the config null
+	if len(attachment.Ref.Parameters) == 0 && (data.Ref == nil || data.Ref.Parameters == nil) {
+		attachment.Ref.Parameters = nil
+	}
+	data.setAttachment(attachment)
+	data.Name = name
+	data.Id = types.StringValue(strings.Join([]string{data.Application.ValueString(), data.ReleaseChannel.ValueString(), data.AttachTo.ValueString(), data.Name.ValueString()}, "/"))
+	return true, nil
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ReleaseChannelProtectionAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Name.IsNull() || data.Name.IsUnknown() {
+		data.Name = data.Ref.Name
+	}
+	attachment, err := data.attachment().AsProto()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create protection attachment, got error: %s", err))
+		return
+	}
+	err = r.updateReleaseChannelAttachments(ctx, data, func(attachments []*prot_pb.ProtectionAttachmentConfig) ([]*prot_pb.ProtectionAttachmentConfig, error) {
+		if findProtectionAttachment(attachments, attachment.Name) >= 0 {
+			return nil, errors.Errorf("Release channel %s already has a protection attachment named %s in %s, use terraform import to manage it", data.ReleaseChannel.ValueString(), attachment.Name, data.AttachTo.ValueString())
+		}
+		return append(attachments, attachment), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create protection attachment, got error: %s", err))
+		return
+	}
+	if _, err := r.refresh(ctx, data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read protection attachment state for %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, "created release channel protection attachment resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ReleaseChannelProtectionAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.refresh(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read protection attachment state for %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	// if the release channel or the attachment no longer exist, remove the resource
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData *ReleaseChannelProtectionAttachmentResourceModel
+	var stateData *ReleaseChannelProtectionAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planData.Name.IsNull() || planData.Name.IsUnknown() {
+		planData.Name = planData.Ref.Name
+	}
+	attachment, err := planData.attachment().AsProto()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update protection attachment, got error: %s", err))
+		return
+	}
+	err = r.updateReleaseChannelAttachments(ctx, planData, func(attachments []*prot_pb.ProtectionAttachmentConfig) ([]*prot_pb.ProtectionAttachmentConfig, error) {
+		if attachment.Name != stateData.Name.ValueString() && findProtectionAttachment(attachments, attachment.Name) >= 0 {
+			return nil, errors.Errorf("Release channel %s already has a protection attachment named %s in %s, use terraform import to manage it", planData.ReleaseChannel.ValueString(), attachment.Name, planData.AttachTo.ValueString())
+		}
+		// replace the attachment in place, it may have been removed outside of Terraform
+		if idx := findProtectionAttachment(attachments, stateData.Name.ValueString()); idx >= 0 {
+			attachments[idx] = attachment
+			return attachments, nil
+		}
+		return append(attachments, attachment), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update protection attachment, got error: %s", err))
+		return
+	}
+	if _, err := r.refresh(ctx, planData); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read protection attachment state for %s, got error: %s", planData.Name.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated release channel protection attachment resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ReleaseChannelProtectionAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.updateReleaseChannelAttachments(ctx, data, func(attachments []*prot_pb.ProtectionAttachmentConfig) ([]*prot_pb.ProtectionAttachmentConfig, error) {
+		idx := findProtectionAttachment(attachments, data.Name.ValueString())
+		if idx < 0 {
+			return attachments, nil
+		}
+		return append(attachments[:idx], attachments[idx+1:]...), nil
+	})
+	// nothing to detach from a release channel that no longer exists
+	if err != nil && status.Code(errors.Cause(err)) != codes.NotFound {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete protection attachment, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "deleted release channel protection attachment resource")
+}
+
+func (r *ReleaseChannelProtectionAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// req.ID is of the form <application>/<release channel>/<attach_to>/<name>
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 4 {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import protection attachment, got error: invalid id %s, expected <application>/<release channel>/<attach_to>/<name>", req.ID))
+		return
+	}
+
+	if !slices.Contains(releaseChannelAttachmentLists, parts[2]) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import protection attachment, got error: invalid attach_to %s, expected one of (%s)", parts[2], strings.Join(releaseChannelAttachmentLists, ", ")))
+		return
+	}
+
+	data := ReleaseChannelProtectionAttachmentResourceModel{
+		Application:    types.StringValue(parts[0]),
+		ReleaseChannel: types.StringValue(parts[1]),
+		AttachTo:       types.StringValue(parts[2]),
+		Name:           types.StringValue(parts[3]),
+	}
+	found, err := r.refresh(ctx, &data)
+	if err == nil && !found {
+		err = errors.Errorf("protection attachment %s not found in %s of release channel %s", parts[3], parts[2], parts[1])
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import protection attachment state for %s, got error: %s", req.ID, err))
+		return
+	}
+
+	// Save imported data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
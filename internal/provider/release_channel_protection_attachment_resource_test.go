@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccReleaseChannelProtectionAttachmentResource(t *testing.T) {
+	appName := uniqueTestName("rc-attachment-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccReleaseChannelProtectionAttachmentResourceConfig(appName, "foo", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel_protection_attachment.test", "id", appName+"/test/protections/mandatory"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_protection_attachment.test", "attach_to", "protections"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_protection_attachment.test", "ref.parameters.0.string_value", "foo"),
+					resource.TestCheckResourceAttr("prodvana_release_channel_protection_attachment.test", "deployment.enabled", "true"),
+					// the release channel only tracks the attachments it manages
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "protections.#", "1"),
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "protections.0.name", "owned"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channel.test", "protections.#", "2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "prodvana_release_channel_protection_attachment.test",
+				ImportStateId:     appName + "/test/protections/mandatory",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// updating the release channel keeps the attachment
+			{
+				Config: testAccReleaseChannelProtectionAttachmentResourceConfig(appName, "foo", `
+  policy = {
+    default_env = {
+      "TEST_VAR" = { value = "test value" }
+    }
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "protections.#", "1"),
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "policy.default_env.TEST_VAR.value", "test value"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channel.test", "protections.#", "2"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channel.test", "protections.1.name", "mandatory"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccReleaseChannelProtectionAttachmentResourceConfig(appName, "bar", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_release_channel_protection_attachment.test", "ref.parameters.0.string_value", "bar"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channel.test", "protections.#", "2"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channel.test", "protections.1.ref.parameters.0.string_value", "bar"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccReleaseChannelProtectionAttachmentResourceParallel(t *testing.T) {
+	appName := uniqueTestName("rc-attachment-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// attachments to the same release channel are created in parallel
+			{
+				Config: testAccReleaseChannelProtectionAttachmentResourceParallelConfig(appName, 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_release_channel.test", "protections.#", "6"),
+				),
+			},
+		},
+	})
+}
+
+func testAccReleaseChannelProtectionAttachmentResourceConfig(app, paramA, extra string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "prodvana_release_channel" "test" {
+  name        = "test"
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+  protections = [
+    {
+      name = "owned"
+      ref = {
+        name = "param-test"
+        parameters = [
+          {
+            name         = "paramA"
+            string_value = "owned"
+          },
+        ]
+      }
+      deployment = {
+        enabled = true
+      }
+    },
+  ]
+  ignore_unmanaged_protections = true
+%[3]s
+}
+
+resource "prodvana_release_channel_protection_attachment" "test" {
+  application     = prodvana_application.app.name
+  release_channel = prodvana_release_channel.test.name
+  name            = "mandatory"
+  ref = {
+    name = "param-test"
+    parameters = [
+      {
+        name         = "paramA"
+        string_value = %[2]q
+      },
+      {
+        name      = "paramB"
+        int_value = 10
+      },
+    ]
+  }
+  deployment = {
+    enabled = true
+  }
+}
+
+data "prodvana_release_channel" "test" {
+  name        = prodvana_release_channel.test.name
+  application = prodvana_application.app.name
+  depends_on  = [prodvana_release_channel_protection_attachment.test]
+}
+`, testAccApplicationResourceConfig(app), paramA, extra)
+}
+
+func testAccReleaseChannelProtectionAttachmentResourceParallelConfig(app string, count int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "prodvana_release_channel" "test" {
+  name        = "test"
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+  protections = [
+    {
+      name = "owned"
+      ref = {
+        name = "param-test"
+        parameters = [
+          {
+            name         = "paramA"
+            string_value = "owned"
+          },
+        ]
+      }
+      deployment = {
+        enabled = true
+      }
+    },
+  ]
+  ignore_unmanaged_protections = true
+}
+
+resource "prodvana_release_channel_protection_attachment" "test" {
+  count           = %[2]d
+  application     = prodvana_application.app.name
+  release_channel = prodvana_release_channel.test.name
+  name            = "mandatory-${count.index}"
+  ref = {
+    name = "param-test"
+    parameters = [
+      {
+        name         = "paramA"
+        string_value = "foo"
+      },
+    ]
+  }
+  deployment = {
+    enabled = true
+  }
+}
+
+data "prodvana_release_channel" "test" {
+  name        = prodvana_release_channel.test.name
+  application = prodvana_application.app.name
+  depends_on  = [prodvana_release_channel_protection_attachment.test]
+}
+`, testAccApplicationResourceConfig(app), count)
+}
@@ -7,19 +7,17 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
-	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
 	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
 	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
 	runtimes_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/runtimes"
 	version_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
 	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
 	"golang.org/x/exp/maps"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -36,6 +34,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ReleaseChannelResource{}
 var _ resource.ResourceWithImportState = &ReleaseChannelResource{}
+var _ resource.ResourceWithModifyPlan = &ReleaseChannelResource{}
 
 func NewReleaseChannelResource() resource.Resource {
 	return &ReleaseChannelResource{}
@@ -43,7 +42,11 @@ func NewReleaseChannelResource() resource.Resource {
 
 // ReleaseChannelResource defines the resource implementation.
 type ReleaseChannelResource struct {
-	client rc_pb.ReleaseChannelManagerClient
+	client             rc_pb.ReleaseChannelManagerClient
+	protectionClient   prot_pb.ProtectionManagerClient
+	plannedProtections *plannedProtections
+	onConflict         string
+	locks              *keyedMutex
 }
 
 // ReleaseChannelResourcrModel describes the resource data model.
@@ -65,12 +68,9 @@ type ReleaseChannelResourceModel struct {
 
 	Constants []*constant `tfsdk:"constants"`
 
-	DisableAllProtections types.Bool `tfsdk:"disable_all_protections"`
-}
-
-type constant struct {
-	Name        types.String `tfsdk:"name"`
-	StringValue types.String `tfsdk:"string_value"`
+	DisableAllProtections      types.Bool `tfsdk:"disable_all_protections"`
+	IgnoreUnmanagedProtections types.Bool `tfsdk:"ignore_unmanaged_protections"`
+	AdoptExisting              types.Bool `tfsdk:"adopt_existing"`
 }
 
 type releaseChannelStable struct {
@@ -84,25 +84,13 @@ type manualApproval struct {
 }
 
 type sharedManualApproval struct {
-	Name types.String `tfsdk:"name"`
+	Name         types.String `tfsdk:"name"`
+	MinApprovers types.Int64  `tfsdk:"min_approvers"`
 }
 
 type policyModel struct {
 	DefaultEnv map[string]*envValue `tfsdk:"default_env"`
 }
-type envValue struct {
-	Value            types.String         `tfsdk:"value"`
-	Secret           *envSecret           `tfsdk:"secret"`
-	KubernetesSecret *envKubernetesSecret `tfsdk:"kubernetes_secret"`
-}
-type envSecret struct {
-	Key     types.String `tfsdk:"key"`
-	Version types.String `tfsdk:"version"`
-}
-type envKubernetesSecret struct {
-	SecretName types.String `tfsdk:"secret_name"`
-	Key        types.String `tfsdk:"key"`
-}
 
 type releaseChannelRuntimeConfig struct {
 	Runtime types.String `tfsdk:"runtime"`
@@ -126,149 +114,7 @@ func (r *ReleaseChannelResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *ReleaseChannelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 
-	protectionSchema := schema.NestedAttributeObject{
-		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				MarkdownDescription: "name of the protection",
-				Optional:            true,
-				Computed:            true,
-				Validators:          validators.DefaultNameValidators(),
-			},
-			"ref": schema.SingleNestedAttribute{
-				MarkdownDescription: "reference to a protection stored in Prodvana",
-				Required:            true,
-				Attributes: map[string]schema.Attribute{
-					"name": schema.StringAttribute{
-						MarkdownDescription: "name of the protection",
-						Required:            true,
-						Validators:          validators.DefaultNameValidators(),
-					},
-					"parameters": schema.ListNestedAttribute{
-						MarkdownDescription: "parameters to pass to the protection",
-						Optional:            true,
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"name": schema.StringAttribute{
-									MarkdownDescription: "name of the parameter",
-									Required:            true,
-								},
-								"string_value": schema.StringAttribute{
-									MarkdownDescription: "parameter string value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
-									Optional:            true,
-									Validators: []validator.String{
-										stringvalidator.ExactlyOneOf(
-											path.MatchRelative().AtParent().AtName("int_value"),
-											path.MatchRelative().AtParent().AtName("docker_image_tag_value"),
-											path.MatchRelative().AtParent().AtName("secret_value"),
-										),
-									},
-								},
-								"int_value": schema.Int64Attribute{
-									MarkdownDescription: "parameter int value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
-									Optional:            true,
-									Validators: []validator.Int64{
-										int64validator.ExactlyOneOf(
-											path.MatchRelative().AtParent().AtName("string_value"),
-											path.MatchRelative().AtParent().AtName("docker_image_tag_value"),
-											path.MatchRelative().AtParent().AtName("secret_value"),
-										),
-									},
-								},
-								"docker_image_tag_value": schema.StringAttribute{
-									MarkdownDescription: "parameter docker image tag value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
-									Optional:            true,
-									Validators: []validator.String{
-										stringvalidator.ExactlyOneOf(
-											path.MatchRelative().AtParent().AtName("string_value"),
-											path.MatchRelative().AtParent().AtName("int_value"),
-											path.MatchRelative().AtParent().AtName("secret_value"),
-										),
-									},
-								},
-								"secret_value": schema.SingleNestedAttribute{
-									MarkdownDescription: "parameter secret value, only one of (string_value, int_value, docker_image_tag_value, secret_value) can be set",
-									Optional:            true,
-									Validators: []validator.Object{
-										objectvalidator.ExactlyOneOf(
-											path.MatchRelative().AtParent().AtName("string_value"),
-											path.MatchRelative().AtParent().AtName("int_value"),
-											path.MatchRelative().AtParent().AtName("docker_image_tag_value"),
-										),
-									},
-									Attributes: map[string]schema.Attribute{
-										"key": schema.StringAttribute{
-											MarkdownDescription: "Name of the secret.",
-											Required:            true,
-										},
-										"version": schema.StringAttribute{
-											MarkdownDescription: "Version of the secret",
-											Required:            true,
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			"pre_approval": schema.SingleNestedAttribute{
-				MarkdownDescription: "pre-approval lifecycle options",
-				Optional:            true,
-				Attributes: map[string]schema.Attribute{
-					"enabled": schema.BoolAttribute{
-						MarkdownDescription: "whether to enable pre-approval lifecycle options",
-						Optional:            true,
-						Computed:            true,
-						Default:             booldefault.StaticBool(false),
-					},
-				},
-			},
-			"post_approval": schema.SingleNestedAttribute{
-				MarkdownDescription: "post-approval lifecycle options",
-				Optional:            true,
-				Attributes: map[string]schema.Attribute{
-					"enabled": schema.BoolAttribute{
-						MarkdownDescription: "whether to enable post-approval lifecycle options",
-						Optional:            true,
-						Computed:            true,
-						Default:             booldefault.StaticBool(false),
-					},
-				},
-			},
-			"deployment": schema.SingleNestedAttribute{
-				MarkdownDescription: "deployment lifecycle options",
-				Optional:            true,
-				Attributes: map[string]schema.Attribute{
-					"enabled": schema.BoolAttribute{
-						MarkdownDescription: "whether to enable deployment lifecycle options",
-						Optional:            true,
-						Computed:            true,
-						Default:             booldefault.StaticBool(false),
-					},
-				},
-			},
-			"post_deployment": schema.SingleNestedAttribute{
-				MarkdownDescription: "post-deployment lifecycle options",
-				Optional:            true,
-				Attributes: map[string]schema.Attribute{
-					"enabled": schema.BoolAttribute{
-						MarkdownDescription: "whether to enable post-deployment lifecycle options",
-						Optional:            true,
-						Computed:            true,
-						Default:             booldefault.StaticBool(false),
-					},
-					"delay_check_duration": schema.StringAttribute{
-						MarkdownDescription: "delay between the deployment completing and when this protection starts checking. A valid Go duration string, e.g. `10m` or `1h`. Defaults to `10m`",
-						Optional:            true,
-					},
-					"check_duration": schema.StringAttribute{
-						MarkdownDescription: "how long to keep checking. A valid Go duration string, e.g. `10m` or `1h`. Defaults to `10m`",
-						Optional:            true,
-					},
-				},
-			},
-		},
-	}
+	protectionSchema := protectionAttachmentNestedObjectSchema()
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "This resource allows you to manage a Prodvana [Release Channel](https://docs.prodvana.io/docs/prodvana-concepts#release-channel).",
 		Attributes: map[string]schema.Attribute{
@@ -304,42 +150,7 @@ func (r *ReleaseChannelResource) Schema(ctx context.Context, req resource.Schema
 				Optional:            true,
 				Attributes: map[string]schema.Attribute{
 					"default_env": schema.MapNestedAttribute{
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"value": schema.StringAttribute{
-									MarkdownDescription: "Non-sensitive environment variable value",
-									Optional:            true,
-								},
-								"secret": schema.SingleNestedAttribute{
-									MarkdownDescription: "Reference to a secret value stored in Prodvana.",
-									Optional:            true,
-									Attributes: map[string]schema.Attribute{
-										"key": schema.StringAttribute{
-											MarkdownDescription: "Name of the secret.",
-											Optional:            true,
-										},
-										"version": schema.StringAttribute{
-											MarkdownDescription: "Version of the secret",
-											Optional:            true,
-										},
-									},
-								},
-								"kubernetes_secret": schema.SingleNestedAttribute{
-									MarkdownDescription: "Reference to a secret value stored in Kubernetes.",
-									Optional:            true,
-									Attributes: map[string]schema.Attribute{
-										"secret_name": schema.StringAttribute{
-											MarkdownDescription: "Name of the secret object",
-											Optional:            true,
-										},
-										"key": schema.StringAttribute{
-											MarkdownDescription: "Key of the secret in the data field of the secret object",
-											Optional:            true,
-										},
-									},
-								},
-							},
-						},
+						NestedObject:        envValueNestedObjectSchema(),
 						MarkdownDescription: "default environment variables for services in this Release Channel",
 						Optional:            true,
 					},
@@ -399,44 +210,12 @@ func (r *ReleaseChannelResource) Schema(ctx context.Context, req resource.Schema
 			"manual_approval_preconditions": schema.ListNestedAttribute{
 				MarkdownDescription: "Preconditions requiring manual approval before this release channel can be deployed",
 				Optional:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							MarkdownDescription: "name of the manual approval",
-							Optional:            true,
-							Computed:            true,
-							Default:             stringdefault.StaticString(""),
-							Validators:          validators.DefaultNameValidators(),
-						},
-						"description": schema.StringAttribute{
-							MarkdownDescription: "description of the manual approval",
-							Optional:            true,
-							Computed:            true,
-							Default:             stringdefault.StaticString(""),
-						},
-						"every_action": schema.BoolAttribute{
-							MarkdownDescription: "whether this approval is required for every convergence action, or just the first. This only works for runtime extensions and Terraform runners. Setting this field to true will not result in any approvals being requested for Kubernetes services.",
-							Optional:            true,
-							Computed:            true,
-							Default:             booldefault.StaticBool(false),
-						},
-					},
-				},
+				NestedObject:        manualApprovalNestedObjectSchema(),
 			},
 			"shared_manual_approval_preconditions": schema.ListNestedAttribute{
 				MarkdownDescription: "Preconditions requiring manual approval before this release channel can be deployed, shared across release channels",
 				Optional:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							MarkdownDescription: "name of the manual approval",
-							Optional:            true,
-							Computed:            true,
-							Default:             stringdefault.StaticString(""),
-							Validators:          validators.DefaultNameValidators(),
-						},
-					},
-				},
+				NestedObject:        sharedManualApprovalNestedObjectSchema(),
 			},
 			"protections": schema.ListNestedAttribute{
 				MarkdownDescription: "Protections applied this release channel",
@@ -456,21 +235,7 @@ func (r *ReleaseChannelResource) Schema(ctx context.Context, req resource.Schema
 			"constants": schema.ListNestedAttribute{
 				MarkdownDescription: "Constant values for this release channel",
 				Optional:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"name": schema.StringAttribute{
-							MarkdownDescription: "name of the constant",
-							Required:            true,
-							Validators: []validator.String{
-								stringvalidator.LengthAtLeast(1),
-							},
-						},
-						"string_value": schema.StringAttribute{
-							MarkdownDescription: "string value of the constant",
-							Required:            true,
-						},
-					},
-				},
+				NestedObject:        constantNestedObjectSchema(),
 			},
 			"disable_all_protections": schema.BoolAttribute{
 				MarkdownDescription: "Disable all protections for this release channel",
@@ -478,6 +243,64 @@ func (r *ReleaseChannelResource) Schema(ctx context.Context, req resource.Schema
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"ignore_unmanaged_protections": schema.BoolAttribute{
+				MarkdownDescription: "Leave protection attachments that are not in `protections`, `convergence_protections` or `service_instance_protections` in place instead of removing them, e.g. attachments managed by `prodvana_release_channel_protection_attachment`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"adopt_existing": adoptExistingSchema("release channel"),
+		},
+	}
+}
+
+// manualApprovalNestedObjectSchema is the schema for a single manual approval
+// precondition.
+func manualApprovalNestedObjectSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "name of the manual approval",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators:          validators.DefaultNameValidators(),
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "description of the manual approval",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"every_action": schema.BoolAttribute{
+				MarkdownDescription: "whether this approval is required for every convergence action, or just the first. This only works for runtime extensions and Terraform runners. Setting this field to true will not result in any approvals being requested for Kubernetes services.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// sharedManualApprovalNestedObjectSchema is the schema for a single shared
+// manual approval precondition.
+func sharedManualApprovalNestedObjectSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "name of the manual approval",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				Validators:          validators.DefaultNameValidators(),
+			},
+			"min_approvers": schema.Int64Attribute{
+				MarkdownDescription: "minimum number of approvers required, set the same value on every release channel sharing the approval",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -488,26 +311,46 @@ func (r *ReleaseChannelResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = rc_pb.NewReleaseChannelManagerClient(conn)
+	r.client = clients.ReleaseChannel
+	r.protectionClient = clients.Protection
+	r.plannedProtections = clients.PlannedProtections
+	r.onConflict = clients.OnConflict
+	r.locks = clients.ReleaseChannelLocks
 }
 
-func attachmentProtosToTerraform(protections []*prot_pb.ProtectionAttachmentConfig) []*protectionAttachment {
-	attachments := make([]*protectionAttachment, len(protections))
-	for idx, pa := range protections {
-		attachments[idx] = ProtectionAttachmentProtoToTerraform(pa)
+// ModifyPlan validates the parameters passed to attached protections against
+// the parameters those protections declare.
+func (r *ReleaseChannelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to validate on destroy, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.protectionClient == nil {
+		return
+	}
+
+	cache := r.plannedProtections.cache()
+	for _, attr := range []string{"protections", "convergence_protections", "service_instance_protections"} {
+		var list types.List
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr), &list)...)
+		if list.IsNull() || list.IsUnknown() {
+			continue
+		}
+		var attachments []*protectionAttachment
+		if diags := list.ElementsAs(ctx, &attachments, false); diags.HasError() {
+			// parts of the attachments are unknown until apply, leave them to the API
+			continue
+		}
+		resp.Diagnostics.Append(validateProtectionAttachmentParameters(ctx, r.protectionClient, path.Root(attr), attachments, cache)...)
 	}
-	return attachments
 }
 
 func readReleaseChannelData(ctx context.Context, client rc_pb.ReleaseChannelManagerClient, data *ReleaseChannelResourceModel) error {
@@ -529,27 +372,8 @@ func readReleaseChannelData(ctx context.Context, client rc_pb.ReleaseChannelMana
 	if config.Policy == nil {
 		data.Policy = nil
 	} else {
-		defaultEnv := make(map[string]*envValue, len(config.Policy.DefaultEnv))
-		for k, v := range config.Policy.DefaultEnv {
-			envVal := &envValue{}
-			switch t := v.ValueOneof.(type) {
-			case *common_config_pb.EnvValue_Value:
-				envVal.Value = types.StringValue(t.Value)
-			case *common_config_pb.EnvValue_Secret:
-				envVal.Secret = &envSecret{
-					Key:     types.StringValue(t.Secret.Key),
-					Version: types.StringValue(t.Secret.Version),
-				}
-			case *common_config_pb.EnvValue_KubernetesSecret:
-				envVal.KubernetesSecret = &envKubernetesSecret{
-					Key:        types.StringValue(t.KubernetesSecret.Key),
-					SecretName: types.StringValue(t.KubernetesSecret.SecretName),
-				}
-			}
-			defaultEnv[k] = envVal
-		}
 		data.Policy = &policyModel{
-			DefaultEnv: defaultEnv,
+			DefaultEnv: envValuesFromProtos(config.Policy.DefaultEnv),
 		}
 	}
 	if config.Runtimes == nil {
@@ -595,7 +419,11 @@ func readReleaseChannelData(ctx context.Context, client rc_pb.ReleaseChannelMana
 				approvals = append(approvals, precon)
 			case *rc_pb.Precondition_SharedManualApproval_:
 				precon := &sharedManualApproval{
-					Name: types.StringValue(rc.GetSharedManualApproval().Name),
+					Name:         types.StringValue(rc.GetSharedManualApproval().Name),
+					MinApprovers: types.Int64Null(),
+				}
+				if minApprovers := rc.GetSharedManualApproval().MinApprovers; minApprovers > 0 {
+					precon.MinApprovers = types.Int64Value(int64(minApprovers))
 				}
 				sharedApprovals = append(sharedApprovals, precon)
 			}
@@ -631,14 +459,7 @@ func readReleaseChannelData(ctx context.Context, client rc_pb.ReleaseChannelMana
 	}
 
 	if config.Constants != nil {
-		constants := []*constant{}
-		for _, c := range config.Constants {
-			constants = append(constants, &constant{
-				Name:        types.StringValue(c.Name),
-				StringValue: types.StringValue(c.GetString_().Value),
-			})
-		}
-		data.Constants = constants
+		data.Constants = constantsFromProtos(config.Constants)
 	}
 
 	data.DisableAllProtections = types.BoolValue(config.DisableAllProtections)
@@ -650,19 +471,9 @@ func (r *ReleaseChannelResource) refresh(ctx context.Context, data *ReleaseChann
 	return readReleaseChannelData(ctx, r.client, data)
 }
 
-func protectionAttachmentsToProtos(attachments []*protectionAttachment) ([]*prot_pb.ProtectionAttachmentConfig, error) {
-	protections := []*prot_pb.ProtectionAttachmentConfig{}
-	for _, protection := range attachments {
-		protoAttachment, err := protection.AsProto()
-		if err != nil {
-			return nil, err
-		}
-		protections = append(protections, protoAttachment)
-	}
-	return protections, nil
-}
-
-func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData *ReleaseChannelResourceModel) error {
+// releaseChannelConfigFromModel builds the release channel config sent to
+// ConfigureReleaseChannel.
+func releaseChannelConfigFromModel(planData *ReleaseChannelResourceModel) (*rc_pb.ReleaseChannelConfig, error) {
 	runtimes := make([]*rc_pb.ReleaseChannelRuntimeConfig, len(planData.Runtimes))
 	for idx, rt := range planData.Runtimes {
 		runtimes[idx] = &rc_pb.ReleaseChannelRuntimeConfig{
@@ -672,7 +483,7 @@ func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData *R
 		if rt.Type.ValueString() != "" {
 			connVal, found := rc_pb.RuntimeConnectionType_value[rt.Type.ValueString()]
 			if !found {
-				return errors.Errorf("Invalid runtime connection type %s, must be one of (%s)", rt.Type.ValueString(), strings.Join(runtimeConnectionTypes, ", "))
+				return nil, errors.Errorf("Invalid runtime connection type %s, must be one of (%s)", rt.Type.ValueString(), strings.Join(runtimeConnectionTypes, ", "))
 			}
 			runtimes[idx].Type = rc_pb.RuntimeConnectionType(connVal)
 		}
@@ -700,46 +511,9 @@ func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData *R
 	}
 	var policy *rc_pb.Policy
 	if planData.Policy != nil {
-		defaultEnv := map[string]*common_config_pb.EnvValue{}
-		for k, v := range planData.Policy.DefaultEnv {
-			envVal := &common_config_pb.EnvValue{}
-
-			setOneofs := 0
-			if !v.Value.IsNull() {
-				setOneofs++
-			}
-			if v.Secret != nil {
-				setOneofs++
-			}
-			if v.KubernetesSecret != nil {
-				setOneofs++
-			}
-			if setOneofs > 1 {
-				return fmt.Errorf("only one of Value or Secret or KubernetesSecret can be set for %s", k)
-			}
-
-			if !v.Value.IsNull() {
-				envVal.ValueOneof = &common_config_pb.EnvValue_Value{
-					Value: v.Value.ValueString(),
-				}
-			} else if v.Secret != nil {
-				envVal.ValueOneof = &common_config_pb.EnvValue_Secret{
-					Secret: &common_config_pb.Secret{
-						Key:     v.Secret.Key.ValueString(),
-						Version: v.Secret.Version.ValueString(),
-					},
-				}
-			} else if v.KubernetesSecret != nil {
-				envVal.ValueOneof = &common_config_pb.EnvValue_KubernetesSecret{
-					KubernetesSecret: &common_config_pb.KubernetesSecret{
-						Key:        v.KubernetesSecret.Key.ValueString(),
-						SecretName: v.KubernetesSecret.SecretName.ValueString(),
-					},
-				}
-			} else {
-				return fmt.Errorf("EnvValue for %s is empty", k)
-			}
-			defaultEnv[k] = envVal
+		defaultEnv, err := envValuesToProtos(planData.Policy.DefaultEnv)
+		if err != nil {
+			return nil, err
 		}
 		if len(defaultEnv) > 0 {
 			policy = &rc_pb.Policy{
@@ -783,7 +557,8 @@ func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData *R
 			preconditions = append(preconditions, &rc_pb.Precondition{
 				Precondition: &rc_pb.Precondition_SharedManualApproval_{
 					SharedManualApproval: &rc_pb.Precondition_SharedManualApproval{
-						Name: approval.Name.ValueString(),
+						Name:         approval.Name.ValueString(),
+						MinApprovers: int32(approval.MinApprovers.ValueInt64()),
 					},
 				},
 			})
@@ -792,32 +567,20 @@ func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData *R
 
 	protections, err := protectionAttachmentsToProtos(planData.Protections)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	convergenceProtections, err := protectionAttachmentsToProtos(planData.ConvergenceProtections)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	svcInstanceProtections, err := protectionAttachmentsToProtos(planData.ServiceInstanceProtections)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	constants := []*common_config_pb.Constant{}
-	if planData.Constants != nil {
-		for _, c := range planData.Constants {
-			constants = append(constants, &common_config_pb.Constant{
-				Name: c.Name.ValueString(),
-				ConfigOneof: &common_config_pb.Constant_String_{
-					String_: &common_config_pb.StringConstant{
-						Value: c.StringValue.ValueString(),
-					},
-				},
-			})
-		}
-	}
+	constants := constantsToProtos(planData.Constants)
 
 	var disableAllProtections bool
 	if !planData.DisableAllProtections.IsNull() {
@@ -835,6 +598,171 @@ func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData *R
 		Constants:                  constants,
 		DisableAllProtections:      disableAllProtections,
 	}
+	return releaseChannel, nil
+}
+
+// protectionAttachmentNames returns the names of attachments, defaulting to
+// the name of the protection they reference like the API does.
+func protectionAttachmentNames(attachments []*protectionAttachment) map[string]bool {
+	names := map[string]bool{}
+	for _, pa := range attachments {
+		if !pa.Name.IsNull() && !pa.Name.IsUnknown() {
+			names[pa.Name.ValueString()] = true
+		} else if pa.Ref != nil {
+			names[pa.Ref.Name.ValueString()] = true
+		}
+	}
+	return names
+}
+
+// managedProtectionAttachments keeps the attachments that are also in managed.
+func managedProtectionAttachments(attachments []*protectionAttachment, managed []*protectionAttachment) []*protectionAttachment {
+	if managed == nil {
+		return nil
+	}
+	names := protectionAttachmentNames(managed)
+	kept := []*protectionAttachment{}
+	for _, pa := range attachments {
+		if names[pa.Name.ValueString()] {
+			kept = append(kept, pa)
+		}
+	}
+	return kept
+}
+
+// keepUnmanagedProtections adds the attachments of the current release channel
+// config that neither the plan nor the prior state know about to
+// releaseChannel.
+func keepUnmanagedProtections(releaseChannel, current *rc_pb.ReleaseChannelConfig, planData, stateData *ReleaseChannelResourceModel) {
+	if stateData == nil {
+		stateData = &ReleaseChannelResourceModel{}
+	}
+	for _, lists := range []struct {
+		attachments *[]*prot_pb.ProtectionAttachmentConfig
+		current     []*prot_pb.ProtectionAttachmentConfig
+		plan, state []*protectionAttachment
+	}{
+		{&releaseChannel.Protections, current.Protections, planData.Protections, stateData.Protections},
+		{&releaseChannel.ConvergenceProtections, current.ConvergenceProtections, planData.ConvergenceProtections, stateData.ConvergenceProtections},
+		{&releaseChannel.ServiceInstanceProtections, current.ServiceInstanceProtections, planData.ServiceInstanceProtections, stateData.ServiceInstanceProtections},
+	} {
+		planNames := protectionAttachmentNames(lists.plan)
+		stateNames := protectionAttachmentNames(lists.state)
+		for _, pa := range lists.current {
+			name := pa.Name
+			if name == "" && pa.Ref != nil {
+				name = pa.Ref.Name
+			}
+			if !planNames[name] && !stateNames[name] {
+				*lists.attachments = append(*lists.attachments, pa)
+			}
+		}
+	}
+}
+
+// checkReleaseChannelConflict fails when the release channel changed since
+// Terraform last read it into stateData. The API has no conditional
+// ConfigureReleaseChannel, so this is checked right before configuring it.
+func checkReleaseChannelConflict(current *rc_pb.ReleaseChannel, stateData *ReleaseChannelResourceModel) error {
+	if current.Meta.Version == stateData.Version.ValueString() {
+		return nil
+	}
+	expected, err := releaseChannelConfigFromModel(stateData)
+	if err != nil {
+		return err
+	}
+	actual := normalizeReleaseChannelConfig(current.Config)
+	if stateData.IgnoreUnmanagedProtections.ValueBool() {
+		for _, lists := range []struct {
+			attachments *[]*prot_pb.ProtectionAttachmentConfig
+			state       []*protectionAttachment
+		}{
+			{&actual.Protections, stateData.Protections},
+			{&actual.ConvergenceProtections, stateData.ConvergenceProtections},
+			{&actual.ServiceInstanceProtections, stateData.ServiceInstanceProtections},
+		} {
+			names := protectionAttachmentNames(lists.state)
+			managed := []*prot_pb.ProtectionAttachmentConfig{}
+			for _, pa := range *lists.attachments {
+				if names[pa.Name] {
+					managed = append(managed, pa)
+				}
+			}
+			*lists.attachments = managed
+		}
+	}
+	expected = normalizeReleaseChannelConfig(expected)
+	// only the version moved, e.g. because of an attachment managed elsewhere
+	if proto.Equal(expected, actual) {
+		return nil
+	}
+	return conflictError("Release channel", stateData.Name.ValueString(), stateData.Version.ValueString(), current.Meta.Version, expected, actual)
+}
+
+// normalizeReleaseChannelConfig fills in the defaults the API applies to a
+// release channel config, so that configs can be compared.
+func normalizeReleaseChannelConfig(config *rc_pb.ReleaseChannelConfig) *rc_pb.ReleaseChannelConfig {
+	config = proto.Clone(config).(*rc_pb.ReleaseChannelConfig)
+	for _, rt := range config.Runtimes {
+		if rt.Name == "" {
+			rt.Name = rt.Runtime
+		}
+		rt.Type = rc_pb.RuntimeConnectionType_UNKNOWN_CONNECTION
+		if co := rt.GetContainerOrchestration(); co != nil && co.GetK8S().GetNamespace() == "" && co.GetEcs().GetPrefix() == "" {
+			rt.Capability = nil
+		}
+	}
+	for _, attachments := range [][]*prot_pb.ProtectionAttachmentConfig{
+		config.Protections,
+		config.ConvergenceProtections,
+		config.ServiceInstanceProtections,
+	} {
+		for _, pa := range attachments {
+			if pa.Name == "" && pa.Ref != nil {
+				pa.Name = pa.Ref.Name
+			}
+		}
+	}
+	return config
+}
+
+func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData, stateData *ReleaseChannelResourceModel) error {
+	releaseChannel, err := releaseChannelConfigFromModel(planData)
+	if err != nil {
+		return err
+	}
+	// keeping unmanaged protections reads and writes back the attachments
+	// prodvana_release_channel_protection_attachment adds in parallel
+	if r.locks != nil {
+		unlock := r.locks.Lock(planData.Application.ValueString() + "/" + planData.Name.ValueString())
+		defer unlock()
+	}
+	getRcResp, err := r.client.GetReleaseChannel(ctx, &rc_pb.GetReleaseChannelReq{
+		Application:    planData.Application.ValueString(),
+		ReleaseChannel: planData.Name.ValueString(),
+	})
+	if stateData == nil && !planData.AdoptExisting.ValueBool() {
+		importId := fmt.Sprintf("%s/%s", planData.Application.ValueString(), planData.Name.ValueString())
+		if err := checkNotExists("release channel", planData.Name.ValueString(), importId, err); err != nil {
+			return err
+		}
+	}
+	if err != nil && status.Code(err) != codes.NotFound {
+		return errors.Wrapf(err, "Unable to read release channel state for %s", planData.Name.ValueString())
+	}
+	ignoreUnmanaged := planData.IgnoreUnmanagedProtections.ValueBool()
+	if err == nil {
+		current := getRcResp.ReleaseChannel
+		if stateData != nil && r.onConflict != onConflictOverwrite {
+			if err := checkReleaseChannelConflict(current, stateData); err != nil {
+				return err
+			}
+		}
+		if ignoreUnmanaged {
+			keepUnmanagedProtections(releaseChannel, current.Config, planData, stateData)
+		}
+	}
+	managed := *planData
 
 	_, err = r.client.ConfigureReleaseChannel(ctx, &rc_pb.ConfigureReleaseChannelReq{
 		ReleaseChannel: releaseChannel,
@@ -845,7 +773,21 @@ func (r *ReleaseChannelResource) createOrUpdate(ctx context.Context, planData *R
 		return err
 	}
 
-	return r.refresh(ctx, planData)
+	if err := r.refresh(ctx, planData); err != nil {
+		return err
+	}
+	if ignoreUnmanaged {
+		filterUnmanagedProtections(planData, &managed)
+	}
+	return nil
+}
+
+// filterUnmanagedProtections drops the attachments of data that are not in
+// managed, so that attachments managed elsewhere do not show up as changes.
+func filterUnmanagedProtections(data, managed *ReleaseChannelResourceModel) {
+	data.Protections = managedProtectionAttachments(data.Protections, managed.Protections)
+	data.ConvergenceProtections = managedProtectionAttachments(data.ConvergenceProtections, managed.ConvergenceProtections)
+	data.ServiceInstanceProtections = managedProtectionAttachments(data.ServiceInstanceProtections, managed.ServiceInstanceProtections)
 }
 
 func (r *ReleaseChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -858,7 +800,7 @@ func (r *ReleaseChannelResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	err := r.createOrUpdate(ctx, data)
+	err := r.createOrUpdate(ctx, data, nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create release channel, got error: %s", err))
 		return
@@ -880,6 +822,7 @@ func (r *ReleaseChannelResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	managed := *data
 	err := r.refresh(ctx, data)
 	if err != nil {
 		// if the release channel does not exist, remove the resource
@@ -890,6 +833,9 @@ func (r *ReleaseChannelResource) Read(ctx context.Context, req resource.ReadRequ
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read release channel state for %s, got error: %s", data.Name.ValueString(), err))
 		return
 	}
+	if data.IgnoreUnmanagedProtections.ValueBool() {
+		filterUnmanagedProtections(data, &managed)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -907,7 +853,7 @@ func (r *ReleaseChannelResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	err := r.createOrUpdate(ctx, planData)
+	err := r.createOrUpdate(ctx, planData, stateData)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update release channel, got error: %s", err))
 		return
@@ -956,6 +902,7 @@ func (r *ReleaseChannelResource) ImportState(ctx context.Context, req resource.I
 
 	data.Application = types.StringValue(parts[0])
 	data.Name = types.StringValue(parts[1])
+	data.IgnoreUnmanagedProtections = types.BoolValue(false)
 	err := r.refresh(ctx, &data)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import release channel state for %s, got error: %s", data.Name.ValueString(), err))
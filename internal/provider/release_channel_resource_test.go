@@ -209,6 +209,8 @@ func TestAccReleaseChannelResourceWithStablePrecondition(t *testing.T) {
 
 					resource.TestCheckResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.0.name", "shared-approval1"),
 					resource.TestCheckResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.1.name", "shared-approval2"),
+					resource.TestCheckNoResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.0.min_approvers"),
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.1.min_approvers", "2"),
 				),
 			},
 			// ImportState testing
@@ -234,6 +236,8 @@ func TestAccReleaseChannelResourceWithStablePrecondition(t *testing.T) {
 
 					resource.TestCheckResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.0.name", "shared-approval1"),
 					resource.TestCheckResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.1.name", "shared-approval2"),
+					resource.TestCheckNoResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.0.min_approvers"),
+					resource.TestCheckResourceAttr("prodvana_release_channel.test", "shared_manual_approval_preconditions.1.min_approvers", "2"),
 				),
 			},
 			{
@@ -486,7 +490,8 @@ resource "prodvana_release_channel" "test" {
 		name = "shared-approval1"
 	},
 	{
-		name = "shared-approval2"
+		name          = "shared-approval2"
+		min_approvers = 2
 	},
   ]
 }
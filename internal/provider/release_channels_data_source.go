@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	rc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/release_channel"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ReleaseChannelsDataSource{}
+
+func NewReleaseChannelsDataSource() datasource.DataSource {
+	return &ReleaseChannelsDataSource{}
+}
+
+// ReleaseChannelsDataSource defines the data source implementation.
+type ReleaseChannelsDataSource struct {
+	client rc_pb.ReleaseChannelManagerClient
+}
+
+type ReleaseChannelsDataSourceModel struct {
+	Id              types.String                     `tfsdk:"id"`
+	Application     types.String                     `tfsdk:"application"`
+	NameRegex       types.String                     `tfsdk:"name_regex"`
+	Labels          []labels.LabelDefinition         `tfsdk:"labels"`
+	Names           []string                         `tfsdk:"names"`
+	ReleaseChannels []*releaseChannelsDataSourceItem `tfsdk:"release_channels"`
+}
+
+type releaseChannelsDataSourceItem struct {
+	Id      types.String             `tfsdk:"id"`
+	Name    types.String             `tfsdk:"name"`
+	Version types.String             `tfsdk:"version"`
+	Labels  []labels.LabelDefinition `tfsdk:"labels"`
+}
+
+func (d *ReleaseChannelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_release_channels"
+}
+
+func (d *ReleaseChannelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := listFilterDataSourceAttributes("release channels")
+	attributes["application"] = schema.StringAttribute{
+		MarkdownDescription: "Name of the Application to list release channels of",
+		Required:            true,
+		Validators:          validators.DefaultNameValidators(),
+	}
+	attributes["release_channels"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Matching release channels, sorted by name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Release Channel identifier",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Release Channel name",
+					Computed:            true,
+				},
+				"version": schema.StringAttribute{
+					MarkdownDescription: "Current Release Channel version",
+					Computed:            true,
+				},
+				"labels": schema.ListNestedAttribute{
+					MarkdownDescription: "Labels of the Release Channel",
+					Computed:            true,
+					NestedObject:        labels.LabelDefinitionNestedObjectDataSourceSchema(),
+				},
+			},
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Release Channels of a Prodvana Application, optionally filtered by name and labels",
+		Attributes:          attributes,
+	}
+}
+
+func (d *ReleaseChannelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.ReleaseChannel
+}
+
+func (d *ReleaseChannelsDataSource) read(ctx context.Context, data *ReleaseChannelsDataSourceModel) error {
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		return err
+	}
+
+	listResp, err := d.client.ListReleaseChannels(ctx, &rc_pb.ListReleaseChannelsReq{
+		Application: data.Application.ValueString(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to list release channels for %s", data.Application.ValueString())
+	}
+
+	rcs := listResp.ReleaseChannels
+	sort.Slice(rcs, func(i, j int) bool {
+		return rcs[i].Meta.Name < rcs[j].Meta.Name
+	})
+
+	data.Id = data.Application
+	data.Names = []string{}
+	data.ReleaseChannels = []*releaseChannelsDataSourceItem{}
+	for _, rc := range rcs {
+		rcLabels := rc.Config.GetLabels()
+		if !filter.matchesName(rc.Meta.Name) || !filter.matchesLabels(rcLabels) {
+			continue
+		}
+		data.Names = append(data.Names, rc.Meta.Name)
+		data.ReleaseChannels = append(data.ReleaseChannels, &releaseChannelsDataSourceItem{
+			Id:      types.StringValue(rc.Meta.Id),
+			Name:    types.StringValue(rc.Meta.Name),
+			Version: types.StringValue(rc.Meta.Version),
+			Labels:  labels.LabelDefinitionProtosToTerraform(rcLabels),
+		})
+	}
+	return nil
+}
+
+func (d *ReleaseChannelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ReleaseChannelsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := d.read(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list release channels for %s, got error: %s", data.Application.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, "read release channels data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccReleaseChannelsDataSource(t *testing.T) {
+	appName := uniqueTestName("rcs-ds")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccReleaseChannelsDataSourceConfig(appName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "names.0", "prod-eu"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "names.1", "prod-us"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "names.2", "staging"),
+					resource.TestCheckResourceAttrPair("data.prodvana_release_channels.test", "release_channels.2.id", "prodvana_release_channel.staging", "id"),
+				),
+			},
+			{
+				Config: testAccReleaseChannelsDataSourceConfig(appName, `name_regex = "^prod-"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "release_channels.0.name", "prod-eu"),
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "release_channels.1.name", "prod-us"),
+				),
+			},
+			{
+				Config: testAccReleaseChannelsDataSourceConfig(appName, `
+  labels = [
+    {
+      label = "tier"
+      value = "critical"
+    },
+  ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_release_channels.test", "names.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccReleaseChannelsDataSourceConfig(app, filter string) string {
+	rcs := ""
+	for _, name := range []string{"staging", "prod-us", "prod-eu"} {
+		rcs += fmt.Sprintf(`
+resource "prodvana_release_channel" %[1]q {
+  name        = %[1]q
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+}
+`, name)
+	}
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+data "prodvana_release_channels" "test" {
+  application = prodvana_application.app.name
+  %[3]s
+  depends_on = [
+    prodvana_release_channel.staging,
+    prodvana_release_channel.prod-us,
+    prodvana_release_channel.prod-eu,
+  ]
+}
+`, testAccApplicationResourceConfig(app), rcs, filter)
+}
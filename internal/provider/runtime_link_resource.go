@@ -6,7 +6,6 @@ import (
 
 	"github.com/pkg/errors"
 	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -78,18 +77,18 @@ func (r *RuntimeLinkResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	conn, ok := req.ProviderData.(*grpc.ClientConn)
+	clients, ok := req.ProviderData.(*ProdvanaClients)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *grpc.ClientConn, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = env_pb.NewEnvironmentManagerClient(conn)
+	r.client = clients.Environment
 }
 
 func (r *RuntimeLinkResource) refresh(ctx context.Context, diags diag.Diagnostics, data *RuntimeLinkResourceModel) (bool, error) {
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/labels"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RuntimesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &RuntimesDataSource{}
+
+func NewRuntimesDataSource() datasource.DataSource {
+	return &RuntimesDataSource{}
+}
+
+// RuntimesDataSource defines the data source implementation.
+type RuntimesDataSource struct {
+	client env_pb.EnvironmentManagerClient
+}
+
+type RuntimesDataSourceModel struct {
+	Id        types.String              `tfsdk:"id"`
+	NameRegex types.String              `tfsdk:"name_regex"`
+	Labels    []labels.LabelDefinition  `tfsdk:"labels"`
+	Selector  []labels.Requirement      `tfsdk:"label_selector"`
+	Names     []string                  `tfsdk:"names"`
+	Runtimes  []*runtimesDataSourceItem `tfsdk:"runtimes"`
+}
+
+type runtimesDataSourceItem struct {
+	Id     types.String             `tfsdk:"id"`
+	Name   types.String             `tfsdk:"name"`
+	Type   types.String             `tfsdk:"type"`
+	Labels []labels.LabelDefinition `tfsdk:"labels"`
+}
+
+func (d *RuntimesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runtimes"
+}
+
+func (d *RuntimesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := listFilterDataSourceAttributes("runtimes")
+	attributes["label_selector"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Only return runtimes whose labels match all of these expressions. Can be combined with `labels`",
+		Optional:            true,
+		NestedObject:        labels.RequirementNestedObjectDataSourceSchema(),
+	}
+	attributes["runtimes"] = schema.ListNestedAttribute{
+		MarkdownDescription: "Matching runtimes, sorted by name",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Runtime identifier",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Runtime name",
+					Computed:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Runtime type, e.g. `K8S` or `ECS`",
+					Computed:            true,
+				},
+				"labels": schema.ListNestedAttribute{
+					MarkdownDescription: "Labels of the runtime",
+					Computed:            true,
+					NestedObject:        labels.LabelDefinitionNestedObjectDataSourceSchema(),
+				},
+			},
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Lists Prodvana Runtimes of every type, optionally filtered by name and labels.
+
+Use ` + "`label_selector`" + ` to select runtimes by label expressions instead of hard-coding their names, e.g. every runtime with ` + "`env=prod`" + ` in ` + "`us-east-1`" + ` or ` + "`us-west-2`" + `.`,
+		Attributes: attributes,
+	}
+}
+
+func (d *RuntimesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.Environment
+}
+
+func (d *RuntimesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var selector []labels.Requirement
+	if diags := req.Config.GetAttribute(ctx, path.Root("label_selector"), &selector); diags.HasError() {
+		// parts of the selector are unknown, validate it on read
+		return
+	}
+	for idx, requirement := range selector {
+		if err := requirement.Validate(); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("label_selector").AtListIndex(idx), "Invalid Label Selector", err.Error())
+		}
+	}
+}
+
+func (d *RuntimesDataSource) read(ctx context.Context, data *RuntimesDataSourceModel) error {
+	filter, err := newListFilter(data.NameRegex, data.Labels)
+	if err != nil {
+		return err
+	}
+	for _, requirement := range data.Selector {
+		if err := requirement.Validate(); err != nil {
+			return err
+		}
+	}
+
+	listResp, err := d.client.ListClusters(ctx, &env_pb.ListClustersReq{})
+	if err != nil {
+		return errors.Wrap(err, "Unable to list runtimes")
+	}
+
+	clusters := listResp.Clusters
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	data.Id = types.StringValue("runtimes")
+	data.Names = []string{}
+	data.Runtimes = []*runtimesDataSourceItem{}
+	for _, cluster := range clusters {
+		clusterLabels := cluster.Config.GetLabels()
+		if !filter.matchesName(cluster.Name) || !filter.matchesLabels(clusterLabels) || !labels.MatchesAll(clusterLabels, data.Selector) {
+			continue
+		}
+		data.Names = append(data.Names, cluster.Name)
+		data.Runtimes = append(data.Runtimes, &runtimesDataSourceItem{
+			Id:     types.StringValue(cluster.Id),
+			Name:   types.StringValue(cluster.Name),
+			Type:   types.StringValue(cluster.Type.String()),
+			Labels: labels.LabelDefinitionProtosToTerraform(clusterLabels),
+		})
+	}
+	return nil
+}
+
+func (d *RuntimesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RuntimesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := d.read(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list runtimes, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "read runtimes data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
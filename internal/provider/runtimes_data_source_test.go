@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRuntimesDataSource(t *testing.T) {
+	prefix := uniqueTestName("runtimes-ds")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Filter by name
+			{
+				Config: testAccRuntimesDataSourceConfig(prefix, fmt.Sprintf(`name_regex = "^%s-"`, prefix)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "names.0", prefix+"-a"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "names.1", prefix+"-b"),
+					resource.TestCheckResourceAttrPair("data.prodvana_runtimes.test", "runtimes.0.id", "prodvana_k8s_runtime.a", "id"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "runtimes.1.labels.#", "2"),
+				),
+			},
+			// Filter by name and labels
+			{
+				Config: testAccRuntimesDataSourceConfig(prefix, fmt.Sprintf(`
+  name_regex = "^%s-"
+  labels = [
+    {
+      label = "env"
+      value = "prod"
+    },
+  ]`, prefix)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "names.0", prefix+"-b"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "runtimes.0.name", prefix+"-b"),
+				),
+			},
+			// Select by label expressions
+			{
+				Config: testAccRuntimesDataSourceConfig(prefix, fmt.Sprintf(`
+  name_regex = "^%s-"
+  label_selector = [
+    {
+      label    = "env"
+      operator = "in"
+      values   = ["prod", "staging"]
+    },
+  ]`, prefix)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "runtimes.0.type", "K8S"),
+					resource.TestCheckResourceAttrPair("data.prodvana_runtimes.test", "runtimes.1.id", "prodvana_k8s_runtime.b", "id"),
+				),
+			},
+			{
+				Config: testAccRuntimesDataSourceConfig(prefix, fmt.Sprintf(`
+  name_regex = "^%s-"
+  label_selector = [
+    {
+      label    = "env"
+      operator = "equals"
+      values   = ["prod"]
+    },
+    {
+      label    = "region"
+      operator = "exists"
+    },
+  ]`, prefix)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "runtimes.0.name", prefix+"-b"),
+					resource.TestCheckResourceAttr("data.prodvana_runtimes.test", "runtimes.0.labels.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRuntimesDataSourceInvalidFilters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "prodvana_runtimes" "test" {
+  name_regex = "("
+}
+`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+a\s+valid\s+regular\s+expression`),
+			},
+			{
+				Config: `
+data "prodvana_runtimes" "test" {
+  label_selector = [
+    {
+      label    = "env"
+      operator = "equals"
+      values   = ["prod", "staging"]
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`requires\s+exactly\s+one\s+value`),
+			},
+		},
+	})
+}
+
+func testAccRuntimesDataSourceConfig(prefix, filter string) string {
+	return fmt.Sprintf(`
+resource "prodvana_k8s_runtime" "a" {
+  name = "%[1]s-a"
+  labels = [
+    {
+      label = "env"
+      value = "staging"
+    },
+  ]
+}
+
+resource "prodvana_k8s_runtime" "b" {
+  name = "%[1]s-b"
+  labels = [
+    {
+      label = "env"
+      value = "prod"
+    },
+    {
+      label = "region"
+      value = "us-east-1"
+    },
+  ]
+}
+
+data "prodvana_runtimes" "test" {
+  %[2]s
+  depends_on = [prodvana_k8s_runtime.a, prodvana_k8s_runtime.b]
+}
+`, prefix, filter)
+}
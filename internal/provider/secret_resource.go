@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	secrets_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/secrets"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SecretResource{}
+var _ resource.ResourceWithImportState = &SecretResource{}
+var _ resource.ResourceWithModifyPlan = &SecretResource{}
+
+func NewSecretResource() resource.Resource {
+	return &SecretResource{}
+}
+
+// SecretResource defines the resource implementation.
+type SecretResource struct {
+	client secrets_pb.SecretsManagerClient
+}
+
+// SecretResourceModel describes the resource data model.
+//
+// The secret value itself is never part of the model, it is read from
+// value_env or value_file whenever it is needed and only its hash is kept.
+type SecretResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Key       types.String `tfsdk:"key"`
+	ValueEnv  types.String `tfsdk:"value_env"`
+	ValueFile types.String `tfsdk:"value_file"`
+	ValueHash types.String `tfsdk:"value_hash"`
+	Version   types.String `tfsdk:"version"`
+}
+
+func (r *SecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (r *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `This resource allows you to manage a Prodvana organization [Secret](https://docs.prodvana.io/docs/prodvana-concepts#secrets).
+Reference its ` + "`key`" + ` and ` + "`version`" + ` wherever a secret is accepted, e.g. ` + "`default_env`" + ` or protection ` + "`secret_value`" + ` parameters.
+
+The secret value is read from an environment variable (` + "`value_env`" + `) or a local file (` + "`value_file`" + `) and is never stored in Terraform state.
+Only an HMAC-SHA256 of the value keyed by the secret ` + "`key`" + ` is stored, so equal values under different keys do not share a hash and a hash cannot be matched against a precomputed table of common values. A change to the value shows up as a change to ` + "`value_hash`" + ` and writes a new version of the secret.
+Prodvana never returns secret values, so if the version in state is deleted outside of Terraform, or the secret is imported, the value is written again on the next apply.
+`,
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Secret key",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Secret identifier, same as `key`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"value_env": schema.StringAttribute{
+				MarkdownDescription: "Name of the environment variable to read the secret value from. Only one of `value_env` and `value_file` can be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("value_env"),
+						path.MatchRoot("value_file"),
+					),
+				},
+			},
+			"value_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a local file to read the secret value from. Only one of `value_env` and `value_file` can be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"value_hash": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded HMAC-SHA256 of the secret value, keyed by the secret `key`",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the secret, changes every time the value is written",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.Secrets
+}
+
+// hashSecretValue returns the value_hash of a secret. The hash is salted with
+// the secret key, which is unique per organization, so that it does not give
+// away which secrets share a value or which common value a secret holds.
+// Keeping only the version would not detect value changes on plan.
+func hashSecretValue(key, value string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// secretValue reads the secret value from wherever the model says it lives.
+// It returns false if the source is not known yet, e.g. when value_file
+// comes from another resource that has not been created.
+func secretValue(data *SecretResourceModel) (string, bool, error) {
+	switch {
+	case data.ValueEnv.IsUnknown() || data.ValueFile.IsUnknown():
+		return "", false, nil
+	case !data.ValueEnv.IsNull():
+		name := data.ValueEnv.ValueString()
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			return "", true, errors.Errorf("environment variable %s is not set or empty", name)
+		}
+		return value, true, nil
+	case !data.ValueFile.IsNull():
+		content, err := os.ReadFile(data.ValueFile.ValueString())
+		if err != nil {
+			return "", true, errors.Wrap(err, "failed to read secret value file")
+		}
+		if len(content) == 0 {
+			return "", true, errors.Errorf("secret value file %s is empty", data.ValueFile.ValueString())
+		}
+		return string(content), true, nil
+	}
+	return "", true, errors.New("one of value_env or value_file must be set")
+}
+
+func secretValuePath(data *SecretResourceModel) path.Path {
+	if !data.ValueFile.IsNull() {
+		return path.Root("value_file")
+	}
+	return path.Root("value_env")
+}
+
+func (r *SecretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planData *SecretResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var stateHash types.String
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("value_hash"), &stateHash)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	value, known, err := secretValue(planData)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(secretValuePath(planData), "Invalid Secret Value", err.Error())
+		return
+	}
+	// the hash is keyed by the secret key, so it needs both to be known
+	if !known || planData.Key.IsUnknown() {
+		planData.ValueHash = types.StringUnknown()
+		planData.Version = types.StringUnknown()
+	} else {
+		hash := hashSecretValue(planData.Key.ValueString(), value)
+		planData.ValueHash = types.StringValue(hash)
+		// a hash mismatch means the value changed, or the version in state
+		// is gone, either way a new version gets written
+		if stateHash.ValueString() != hash {
+			planData.Version = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &planData)...)
+}
+
+func (r *SecretResource) write(ctx context.Context, data *SecretResourceModel) error {
+	value, _, err := secretValue(data)
+	if err != nil {
+		return err
+	}
+	hash := hashSecretValue(data.Key.ValueString(), value)
+	if !data.ValueHash.IsUnknown() && data.ValueHash.ValueString() != hash {
+		return errors.New("secret value changed between plan and apply, run terraform apply again")
+	}
+
+	setResp, err := r.client.SetSecret(ctx, &secrets_pb.SetSecretReq{
+		Key:   data.Key.ValueString(),
+		Value: value,
+	})
+	if err != nil {
+		return err
+	}
+
+	data.Id = data.Key
+	data.ValueHash = types.StringValue(hash)
+	data.Version = types.StringValue(setResp.Version)
+	return nil
+}
+
+func (r *SecretResource) refresh(ctx context.Context, data *SecretResourceModel) error {
+	versionsResp, err := r.client.ListSecretVersions(ctx, &secrets_pb.ListSecretVersionsReq{
+		Key: data.Key.ValueString(),
+	})
+	if err != nil {
+		return err
+	}
+	if len(versionsResp.Versions) == 0 {
+		return status.Errorf(codes.NotFound, "secret %s has no versions", data.Key.ValueString())
+	}
+
+	data.Id = data.Key
+	if data.Version.IsNull() {
+		// imported, take the latest version of the secret
+		listResp, err := r.client.ListSecrets(ctx, &secrets_pb.ListSecretsReq{})
+		if err != nil {
+			return err
+		}
+		for _, secret := range listResp.Secrets {
+			if secret.Key == data.Key.ValueString() {
+				data.Version = types.StringValue(secret.Version)
+			}
+		}
+		return nil
+	}
+
+	for _, version := range versionsResp.Versions {
+		if version == data.Version.ValueString() {
+			return nil
+		}
+	}
+	// the version we wrote is gone, the value we hashed is no longer what
+	// the secret holds
+	tflog.Warn(ctx, "secret version not found, value will be written again", map[string]interface{}{
+		"key":     data.Key.ValueString(),
+		"version": data.Version.ValueString(),
+	})
+	data.ValueHash = types.StringNull()
+	return nil
+}
+
+func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *SecretResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.write(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create secret %s, got error: %s", data.Key.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, "created secret resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *SecretResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.refresh(ctx, data)
+	if err != nil {
+		// if secret doesn't exist anymore, remove the resource
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret state for %s, got error: %s", data.Key.ValueString(), err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData *SecretResourceModel
+	var stateData *SecretResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// switching between value_env and value_file with the same value does
+	// not need a new version
+	if planData.ValueHash.IsUnknown() || !planData.ValueHash.Equal(stateData.ValueHash) {
+		err := r.write(ctx, planData)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update secret %s, got error: %s", planData.Key.ValueString(), err))
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated secret resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *SecretResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.DeleteSecret(ctx, &secrets_pb.DeleteSecretReq{
+		Key: data.Key.ValueString(),
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete secret %s, got error: %s", data.Key.ValueString(), err))
+		return
+	}
+
+	tflog.Trace(ctx, "deleted secret resource")
+}
+
+func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), req.ID)...)
+}
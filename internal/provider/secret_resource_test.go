@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSecretResource(t *testing.T) {
+	key := uniqueTestName("secret-tests")
+	envVar := "PVN_TEST_SECRET_VALUE"
+	valueFile := filepath.Join(t.TempDir(), "secret")
+	t.Setenv(envVar, "s3cret-1")
+
+	var firstVersion, secondVersion string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccSecretResourceConfig(key, fmt.Sprintf(`value_env = %q`, envVar)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_secret.test", "key", key),
+					resource.TestCheckResourceAttr("prodvana_secret.test", "id", key),
+					resource.TestCheckResourceAttr("prodvana_secret.test", "value_hash", hashSecretValue(key, "s3cret-1")),
+					resource.TestCheckResourceAttrWith("prodvana_secret.test", "version", func(v string) error {
+						firstVersion = v
+						return nil
+					}),
+					testAccCheckSecretValue("prodvana_secret.test", "s3cret-1"),
+					testAccCheckSecretNotInState("s3cret-1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "prodvana_secret.test",
+				ImportStateId:     key,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the value source is not known to Prodvana and is written again after import
+				ImportStateVerifyIgnore: []string{"value_env", "value_file", "value_hash"},
+			},
+			// Changing the value writes a new version
+			{
+				PreConfig: func() { t.Setenv(envVar, "s3cret-2") },
+				Config:    testAccSecretResourceConfig(key, fmt.Sprintf(`value_env = %q`, envVar)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_secret.test", "value_hash", hashSecretValue(key, "s3cret-2")),
+					resource.TestCheckResourceAttrWith("prodvana_secret.test", "version", func(v string) error {
+						if v == firstVersion {
+							return fmt.Errorf("expected a new version, still at %s", v)
+						}
+						secondVersion = v
+						return nil
+					}),
+					testAccCheckSecretValue("prodvana_secret.test", "s3cret-2"),
+					testAccCheckSecretNotInState("s3cret-2"),
+				),
+			},
+			// Moving the same value to a file keeps the version
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(valueFile, []byte("s3cret-2"), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSecretResourceConfig(key, fmt.Sprintf(`value_file = %q`, valueFile)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_secret.test", "value_file", valueFile),
+					resource.TestCheckResourceAttr("prodvana_secret.test", "value_hash", hashSecretValue(key, "s3cret-2")),
+					resource.TestCheckResourceAttrWith("prodvana_secret.test", "version", func(v string) error {
+						if v != secondVersion {
+							return fmt.Errorf("expected version %s to be kept, got %s", secondVersion, v)
+						}
+						return nil
+					}),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccSecretResourceDrift(t *testing.T) {
+	if !testAccUseFakeServer {
+		t.Skip("deleting secret versions behind the provider's back requires the fake server")
+	}
+	key := uniqueTestName("secret-tests")
+	envVar := "PVN_TEST_SECRET_VALUE"
+	t.Setenv(envVar, "s3cret-1")
+
+	var version string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecretResourceConfig(key, fmt.Sprintf(`value_env = %q`, envVar)),
+				Check: resource.TestCheckResourceAttrWith("prodvana_secret.test", "version", func(v string) error {
+					version = v
+					return nil
+				}),
+			},
+			// Another version is written so the secret itself survives
+			{
+				PreConfig: func() { t.Setenv(envVar, "s3cret-2") },
+				Config:    testAccSecretResourceConfig(key, fmt.Sprintf(`value_env = %q`, envVar)),
+				Check: resource.TestCheckResourceAttrWith("prodvana_secret.test", "version", func(v string) error {
+					version = v
+					return nil
+				}),
+			},
+			// The version in state disappearing is drift, the value is written again
+			{
+				PreConfig: func() {
+					srv, _ := testAccFakeServer()
+					if err := srv.DeleteSecretVersion(key, version); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSecretResourceConfig(key, fmt.Sprintf(`value_env = %q`, envVar)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_secret.test", "value_hash", hashSecretValue(key, "s3cret-2")),
+					resource.TestCheckResourceAttrWith("prodvana_secret.test", "version", func(v string) error {
+						if v == version {
+							return fmt.Errorf("expected a new version after %s was deleted", v)
+						}
+						return nil
+					}),
+					testAccCheckSecretValue("prodvana_secret.test", "s3cret-2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSecretResourceMissingValue(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSecretResourceConfig("missing-value", `value_env = "PVN_TEST_SECRET_UNSET"`),
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+PVN_TEST_SECRET_UNSET\s+is\s+not\s+set`),
+			},
+		},
+	})
+}
+
+// testAccCheckSecretValue checks the value the provider wrote to the fake server.
+func testAccCheckSecretValue(name, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !testAccUseFakeServer {
+			return nil
+		}
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		srv, err := testAccFakeServer()
+		if err != nil {
+			return err
+		}
+		value, ok := srv.SecretValue(rs.Primary.Attributes["key"], rs.Primary.Attributes["version"])
+		if !ok {
+			return fmt.Errorf("secret %s version %s not found", rs.Primary.Attributes["key"], rs.Primary.Attributes["version"])
+		}
+		if value != expected {
+			return fmt.Errorf("expected secret value %q, got %q", expected, value)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSecretNotInState(value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for name, rs := range s.RootModule().Resources {
+			for attr, v := range rs.Primary.Attributes {
+				if v == value {
+					return fmt.Errorf("secret value found in state at %s.%s", name, attr)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func testAccSecretResourceConfig(key, source string) string {
+	return fmt.Sprintf(`
+resource "prodvana_secret" "test" {
+  key = %[1]q
+  %[2]s
+}
+`, key, source)
+}
@@ -0,0 +1,787 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	common_config_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/common_config"
+	prot_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/protection"
+	svc_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/service"
+	version_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/version"
+	"github.com/prodvana/terraform-provider-prodvana/internal/provider/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ServiceResource{}
+var _ resource.ResourceWithImportState = &ServiceResource{}
+
+func NewServiceResource() resource.Resource {
+	return &ServiceResource{}
+}
+
+// ServiceResource defines the resource implementation.
+type ServiceResource struct {
+	client svc_pb.ServiceManagerClient
+}
+
+// ServiceResourceModel describes the resource data model.
+type ServiceResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Application types.String `tfsdk:"application"`
+	Version     types.String `tfsdk:"version"`
+
+	Programs               []*serviceProgram           `tfsdk:"programs"`
+	Replicas               types.Int64                 `tfsdk:"replicas"`
+	Env                    map[string]*envValue        `tfsdk:"env"`
+	Constants              []*constant                 `tfsdk:"constants"`
+	ConvergenceProtections []*protectionAttachment     `tfsdk:"convergence_protections"`
+	PerReleaseChannel      []*servicePerReleaseChannel `tfsdk:"per_release_channel"`
+
+	NoCleanupOnDelete types.Bool `tfsdk:"no_cleanup_on_delete"`
+}
+
+type serviceProgram struct {
+	Name          types.String          `tfsdk:"name"`
+	Image         types.String          `tfsdk:"image"`
+	ImageRegistry *serviceImageRegistry `tfsdk:"image_registry"`
+	ImageTag      types.String          `tfsdk:"image_tag"`
+	Cmd           []types.String        `tfsdk:"cmd"`
+	Entrypoint    []types.String        `tfsdk:"entrypoint"`
+	Env           map[string]*envValue  `tfsdk:"env"`
+	Ports         []*servicePort        `tfsdk:"ports"`
+}
+
+type serviceImageRegistry struct {
+	ContainerRegistry types.String `tfsdk:"container_registry"`
+	Repository        types.String `tfsdk:"repository"`
+}
+
+type servicePort struct {
+	Port       types.Int64  `tfsdk:"port"`
+	TargetPort types.Int64  `tfsdk:"target_port"`
+	External   types.Bool   `tfsdk:"external"`
+	Protocol   types.String `tfsdk:"protocol"`
+}
+
+type servicePerReleaseChannel struct {
+	ReleaseChannel         types.String                       `tfsdk:"release_channel"`
+	Programs               []*servicePerReleaseChannelProgram `tfsdk:"programs"`
+	Replicas               types.Int64                        `tfsdk:"replicas"`
+	Env                    map[string]*envValue               `tfsdk:"env"`
+	Constants              []*constant                        `tfsdk:"constants"`
+	Protections            []*protectionAttachment            `tfsdk:"protections"`
+	ConvergenceProtections []*protectionAttachment            `tfsdk:"convergence_protections"`
+}
+
+type servicePerReleaseChannelProgram struct {
+	Name     types.String         `tfsdk:"name"`
+	Image    types.String         `tfsdk:"image"`
+	ImageTag types.String         `tfsdk:"image_tag"`
+	Env      map[string]*envValue `tfsdk:"env"`
+}
+
+var portProtocols []string
+
+func init() {
+	for k, v := range common_config_pb.PortConfig_Protocol_name {
+		if k != int32(common_config_pb.PortConfig_UNKNOWN) {
+			portProtocols = append(portProtocols, v)
+		}
+	}
+	sort.Strings(portProtocols)
+}
+
+func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service"
+}
+
+func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	protectionSchema := protectionAttachmentNestedObjectSchema()
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "This resource allows you to manage a Prodvana [Service](https://docs.prodvana.io/docs/prodvana-concepts#service) config.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Service name",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"application": schema.StringAttribute{
+				MarkdownDescription: "Name of the Application this Service belongs to",
+				Required:            true,
+				Validators:          validators.DefaultNameValidators(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Current service config version",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Service identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"programs": schema.ListNestedAttribute{
+				MarkdownDescription: "Programs (containers) that make up this service",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "name of the program",
+							Required:            true,
+							Validators:          validators.DefaultNameValidators(),
+						},
+						"image": schema.StringAttribute{
+							MarkdownDescription: "full image reference, e.g. `nginx:1.25`. Cannot be set together with `image_registry`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("image_registry")),
+							},
+						},
+						"image_registry": schema.SingleNestedAttribute{
+							MarkdownDescription: "container registry and repository to pull the image from, used together with `image_tag`",
+							Optional:            true,
+							Attributes: map[string]schema.Attribute{
+								"container_registry": schema.StringAttribute{
+									MarkdownDescription: "name of a container registry linked to Prodvana",
+									Required:            true,
+								},
+								"repository": schema.StringAttribute{
+									MarkdownDescription: "image repository within the container registry",
+									Required:            true,
+								},
+							},
+						},
+						"image_tag": schema.StringAttribute{
+							MarkdownDescription: "image tag to deploy from `image_registry`",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("image_registry")),
+							},
+						},
+						"cmd": schema.ListAttribute{
+							MarkdownDescription: "command to run, overrides the image's CMD",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"entrypoint": schema.ListAttribute{
+							MarkdownDescription: "entrypoint to run, overrides the image's ENTRYPOINT",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"env": schema.MapNestedAttribute{
+							MarkdownDescription: "environment variables for this program",
+							Optional:            true,
+							NestedObject:        envValueNestedObjectSchema(),
+						},
+						"ports": schema.ListNestedAttribute{
+							MarkdownDescription: "ports exposed by this program",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"port": schema.Int64Attribute{
+										MarkdownDescription: "port exposed by the service",
+										Required:            true,
+										Validators: []validator.Int64{
+											int64validator.Between(1, 65535),
+										},
+									},
+									"target_port": schema.Int64Attribute{
+										MarkdownDescription: "port the program listens on, defaults to `port`",
+										Optional:            true,
+										Computed:            true,
+										Validators: []validator.Int64{
+											int64validator.Between(1, 65535),
+										},
+									},
+									"external": schema.BoolAttribute{
+										MarkdownDescription: "whether the port is exposed outside the cluster",
+										Optional:            true,
+										Computed:            true,
+										Default:             booldefault.StaticBool(false),
+									},
+									"protocol": schema.StringAttribute{
+										MarkdownDescription: fmt.Sprintf("protocol of the port, one of (%s)", strings.Join(portProtocols, ", ")),
+										Optional:            true,
+										Computed:            true,
+										Default:             stringdefault.StaticString(common_config_pb.PortConfig_HTTP.String()),
+										Validators: []validator.String{
+											stringvalidator.OneOf(portProtocols...),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"replicas": schema.Int64Attribute{
+				MarkdownDescription: "fixed number of replicas",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"env": schema.MapNestedAttribute{
+				MarkdownDescription: "environment variables applied to every program in this service",
+				Optional:            true,
+				NestedObject:        envValueNestedObjectSchema(),
+			},
+			"constants": schema.ListNestedAttribute{
+				MarkdownDescription: "Constant values for this service",
+				Optional:            true,
+				NestedObject:        constantNestedObjectSchema(),
+			},
+			"convergence_protections": schema.ListNestedAttribute{
+				MarkdownDescription: "Protections applied while this service converges",
+				Optional:            true,
+				NestedObject:        protectionSchema,
+			},
+			"per_release_channel": schema.ListNestedAttribute{
+				MarkdownDescription: "Per release channel overrides",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"release_channel": schema.StringAttribute{
+							MarkdownDescription: "name of the release channel these overrides apply to",
+							Required:            true,
+							Validators:          validators.DefaultNameValidators(),
+						},
+						"programs": schema.ListNestedAttribute{
+							MarkdownDescription: "per program overrides, `name` must match a program in `programs`",
+							Optional:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "name of the program to override",
+										Required:            true,
+										Validators:          validators.DefaultNameValidators(),
+									},
+									"image": schema.StringAttribute{
+										MarkdownDescription: "full image reference override",
+										Optional:            true,
+										Validators: []validator.String{
+											stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("image_tag")),
+										},
+									},
+									"image_tag": schema.StringAttribute{
+										MarkdownDescription: "image tag override, pulled from the program's `image_registry`",
+										Optional:            true,
+									},
+									"env": schema.MapNestedAttribute{
+										MarkdownDescription: "environment variable overrides for this program",
+										Optional:            true,
+										NestedObject:        envValueNestedObjectSchema(),
+									},
+								},
+							},
+						},
+						"replicas": schema.Int64Attribute{
+							MarkdownDescription: "fixed number of replicas in this release channel",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+						"env": schema.MapNestedAttribute{
+							MarkdownDescription: "environment variable overrides for this release channel",
+							Optional:            true,
+							NestedObject:        envValueNestedObjectSchema(),
+						},
+						"constants": schema.ListNestedAttribute{
+							MarkdownDescription: "constant overrides for this release channel",
+							Optional:            true,
+							NestedObject:        constantNestedObjectSchema(),
+						},
+						"protections": schema.ListNestedAttribute{
+							MarkdownDescription: "Protections applied to this service in this release channel",
+							Optional:            true,
+							NestedObject:        protectionSchema,
+						},
+						"convergence_protections": schema.ListNestedAttribute{
+							MarkdownDescription: "Protections applied while this service converges in this release channel",
+							Optional:            true,
+							NestedObject:        protectionSchema,
+						},
+					},
+				},
+			},
+			"no_cleanup_on_delete": schema.BoolAttribute{
+				MarkdownDescription: "Prevent the service from being deleted when the resource is destroyed",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *ServiceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{}
+}
+
+func (r *ServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ProdvanaClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ProdvanaClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.Service
+}
+
+func stringsFromTerraform(values []types.String) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, len(values))
+	for idx, v := range values {
+		out[idx] = v.ValueString()
+	}
+	return out
+}
+
+func stringsToTerraform(values []string) []types.String {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]types.String, len(values))
+	for idx, v := range values {
+		out[idx] = types.StringValue(v)
+	}
+	return out
+}
+
+func replicasToProto(replicas types.Int64) *svc_pb.ReplicasConfig {
+	if replicas.IsNull() || replicas.IsUnknown() {
+		return nil
+	}
+	return &svc_pb.ReplicasConfig{
+		ConfigOneof: &svc_pb.ReplicasConfig_Fixed{
+			Fixed: int32(replicas.ValueInt64()),
+		},
+	}
+}
+
+func replicasFromProto(replicas *svc_pb.ReplicasConfig) types.Int64 {
+	if fixed, ok := replicas.GetConfigOneof().(*svc_pb.ReplicasConfig_Fixed); ok {
+		return types.Int64Value(int64(fixed.Fixed))
+	}
+	return types.Int64Null()
+}
+
+// optionalEnvValuesFromProtos keeps unset env maps null in state.
+func optionalEnvValuesFromProtos(env map[string]*common_config_pb.EnvValue) map[string]*envValue {
+	if len(env) == 0 {
+		return nil
+	}
+	return envValuesFromProtos(env)
+}
+
+// optionalAttachmentProtosToTerraform keeps unset protection lists null in state.
+func optionalAttachmentProtosToTerraform(protections []*prot_pb.ProtectionAttachmentConfig) []*protectionAttachment {
+	if len(protections) == 0 {
+		return nil
+	}
+	return attachmentProtosToTerraform(protections)
+}
+
+func programsFromProtos(programs []*common_config_pb.ProgramConfig) []*serviceProgram {
+	tfPrograms := make([]*serviceProgram, len(programs))
+	for idx, p := range programs {
+		program := &serviceProgram{
+			Name:       types.StringValue(p.Name),
+			Cmd:        stringsToTerraform(p.Cmd),
+			Entrypoint: stringsToTerraform(p.Entrypoint),
+			Env:        optionalEnvValuesFromProtos(p.Env),
+		}
+		if p.Image != "" {
+			program.Image = types.StringValue(p.Image)
+		}
+		if p.ImageTag != "" {
+			program.ImageTag = types.StringValue(p.ImageTag)
+		}
+		if info := p.ImageRegistryInfo; info != nil {
+			program.ImageRegistry = &serviceImageRegistry{
+				ContainerRegistry: types.StringValue(info.ContainerRegistry),
+				Repository:        types.StringValue(info.ImageRepository),
+			}
+		}
+		for _, port := range p.Ports {
+			tfPort := &servicePort{
+				Port:     types.Int64Value(int64(port.Port)),
+				External: types.BoolValue(port.External),
+				Protocol: types.StringValue(port.Protocol.String()),
+			}
+			if port.TargetPort != 0 {
+				tfPort.TargetPort = types.Int64Value(int64(port.TargetPort))
+			} else {
+				tfPort.TargetPort = tfPort.Port
+			}
+			program.Ports = append(program.Ports, tfPort)
+		}
+		tfPrograms[idx] = program
+	}
+	return tfPrograms
+}
+
+func programsToProtos(programs []*serviceProgram) ([]*common_config_pb.ProgramConfig, error) {
+	protos := make([]*common_config_pb.ProgramConfig, len(programs))
+	for idx, p := range programs {
+		env, err := envValuesToProtos(p.Env)
+		if err != nil {
+			return nil, err
+		}
+		program := &common_config_pb.ProgramConfig{
+			Name:       p.Name.ValueString(),
+			Image:      p.Image.ValueString(),
+			ImageTag:   p.ImageTag.ValueString(),
+			Cmd:        stringsFromTerraform(p.Cmd),
+			Entrypoint: stringsFromTerraform(p.Entrypoint),
+			Env:        env,
+		}
+		if p.ImageRegistry != nil {
+			program.ImageRegistryInfo = &common_config_pb.ImageRegistryInfo{
+				ContainerRegistry: p.ImageRegistry.ContainerRegistry.ValueString(),
+				ImageRepository:   p.ImageRegistry.Repository.ValueString(),
+			}
+		}
+		for _, port := range p.Ports {
+			protocol, found := common_config_pb.PortConfig_Protocol_value[port.Protocol.ValueString()]
+			if !found {
+				return nil, errors.Errorf("Invalid port protocol %s, must be one of (%s)", port.Protocol.ValueString(), strings.Join(portProtocols, ", "))
+			}
+			portConfig := &common_config_pb.PortConfig{
+				Port:     int32(port.Port.ValueInt64()),
+				External: port.External.ValueBool(),
+				Protocol: common_config_pb.PortConfig_Protocol(protocol),
+			}
+			if !port.TargetPort.IsNull() && !port.TargetPort.IsUnknown() {
+				portConfig.TargetPort = int32(port.TargetPort.ValueInt64())
+			}
+			program.Ports = append(program.Ports, portConfig)
+		}
+		protos[idx] = program
+	}
+	return protos, nil
+}
+
+func perReleaseChannelFromProtos(configs []*svc_pb.PerReleaseChannelConfig) []*servicePerReleaseChannel {
+	perRcs := make([]*servicePerReleaseChannel, len(configs))
+	for idx, c := range configs {
+		perRc := &servicePerReleaseChannel{
+			ReleaseChannel:         types.StringValue(c.ReleaseChannel),
+			Replicas:               replicasFromProto(c.Replicas),
+			Env:                    optionalEnvValuesFromProtos(c.Env),
+			Protections:            optionalAttachmentProtosToTerraform(c.Protections),
+			ConvergenceProtections: optionalAttachmentProtosToTerraform(c.ConvergenceProtections),
+		}
+		if len(c.Constants) > 0 {
+			perRc.Constants = constantsFromProtos(c.Constants)
+		}
+		for _, p := range c.Programs {
+			program := &servicePerReleaseChannelProgram{
+				Name: types.StringValue(p.Name),
+				Env:  optionalEnvValuesFromProtos(p.Env),
+			}
+			if p.Image != "" {
+				program.Image = types.StringValue(p.Image)
+			}
+			if p.ImageTag != "" {
+				program.ImageTag = types.StringValue(p.ImageTag)
+			}
+			perRc.Programs = append(perRc.Programs, program)
+		}
+		perRcs[idx] = perRc
+	}
+	return perRcs
+}
+
+func perReleaseChannelToProtos(perRcs []*servicePerReleaseChannel) ([]*svc_pb.PerReleaseChannelConfig, error) {
+	protos := make([]*svc_pb.PerReleaseChannelConfig, len(perRcs))
+	for idx, perRc := range perRcs {
+		env, err := envValuesToProtos(perRc.Env)
+		if err != nil {
+			return nil, err
+		}
+		protections, err := protectionAttachmentsToProtos(perRc.Protections)
+		if err != nil {
+			return nil, err
+		}
+		convergenceProtections, err := protectionAttachmentsToProtos(perRc.ConvergenceProtections)
+		if err != nil {
+			return nil, err
+		}
+		config := &svc_pb.PerReleaseChannelConfig{
+			ReleaseChannel:         perRc.ReleaseChannel.ValueString(),
+			Replicas:               replicasToProto(perRc.Replicas),
+			Env:                    env,
+			Constants:              constantsToProtos(perRc.Constants),
+			Protections:            protections,
+			ConvergenceProtections: convergenceProtections,
+		}
+		for _, p := range perRc.Programs {
+			programEnv, err := envValuesToProtos(p.Env)
+			if err != nil {
+				return nil, err
+			}
+			config.Programs = append(config.Programs, &common_config_pb.PerReleaseChannelProgramConfig{
+				Name:     p.Name.ValueString(),
+				Image:    p.Image.ValueString(),
+				ImageTag: p.ImageTag.ValueString(),
+				Env:      programEnv,
+			})
+		}
+		protos[idx] = config
+	}
+	return protos, nil
+}
+
+func readServiceData(ctx context.Context, client svc_pb.ServiceManagerClient, data *ServiceResourceModel) error {
+	svcResp, err := client.GetService(ctx, &svc_pb.GetServiceReq{
+		Application: data.Application.ValueString(),
+		Service:     data.Name.ValueString(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read service state for %s", data.Name.ValueString())
+	}
+	configResp, err := client.GetServiceConfig(ctx, &svc_pb.GetServiceConfigReq{
+		Application: data.Application.ValueString(),
+		Service:     data.Name.ValueString(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Unable to read service config for %s", data.Name.ValueString())
+	}
+
+	// prefer the config as submitted, the compiled config has server-side defaults applied
+	config := configResp.InputConfig
+	if config == nil {
+		config = configResp.CompiledConfig
+	}
+	if config == nil {
+		return errors.Errorf("Service %s has no config", data.Name.ValueString())
+	}
+
+	data.Id = types.StringValue(svcResp.GetService().GetMeta().GetId())
+	data.Version = types.StringValue(configResp.ConfigVersion)
+	data.Programs = programsFromProtos(config.Programs)
+	data.Replicas = replicasFromProto(config.Replicas)
+	data.Env = optionalEnvValuesFromProtos(config.Env)
+	data.ConvergenceProtections = optionalAttachmentProtosToTerraform(config.ConvergenceProtections)
+	data.NoCleanupOnDelete = types.BoolValue(config.NoCleanupOnDelete)
+	if len(config.Constants) > 0 {
+		data.Constants = constantsFromProtos(config.Constants)
+	} else {
+		data.Constants = nil
+	}
+	if len(config.PerReleaseChannel) > 0 {
+		data.PerReleaseChannel = perReleaseChannelFromProtos(config.PerReleaseChannel)
+	} else {
+		data.PerReleaseChannel = nil
+	}
+
+	return nil
+}
+
+func (r *ServiceResource) refresh(ctx context.Context, data *ServiceResourceModel) error {
+	return readServiceData(ctx, r.client, data)
+}
+
+func (r *ServiceResource) createOrUpdate(ctx context.Context, planData *ServiceResourceModel) error {
+	programs, err := programsToProtos(planData.Programs)
+	if err != nil {
+		return err
+	}
+	env, err := envValuesToProtos(planData.Env)
+	if err != nil {
+		return err
+	}
+	convergenceProtections, err := protectionAttachmentsToProtos(planData.ConvergenceProtections)
+	if err != nil {
+		return err
+	}
+	perReleaseChannel, err := perReleaseChannelToProtos(planData.PerReleaseChannel)
+	if err != nil {
+		return err
+	}
+
+	serviceConfig := &svc_pb.ServiceConfig{
+		Name:                   planData.Name.ValueString(),
+		Application:            planData.Application.ValueString(),
+		Programs:               programs,
+		Replicas:               replicasToProto(planData.Replicas),
+		Env:                    env,
+		Constants:              constantsToProtos(planData.Constants),
+		ConvergenceProtections: convergenceProtections,
+		PerReleaseChannel:      perReleaseChannel,
+		NoCleanupOnDelete:      planData.NoCleanupOnDelete.ValueBool(),
+	}
+
+	_, err = r.client.ConfigureService(ctx, &svc_pb.ConfigureServiceReq{
+		Application:   planData.Application.ValueString(),
+		ServiceConfig: serviceConfig,
+		Source:        version_pb.Source_IAC,
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.refresh(ctx, planData)
+}
+
+func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *ServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.createOrUpdate(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create service, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created service resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *ServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.refresh(ctx, data)
+	if err != nil {
+		// if the service does not exist, remove the resource
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read service state for %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var planData *ServiceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.createOrUpdate(ctx, planData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update service, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "updated service resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &planData)...)
+}
+
+func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *ServiceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.NoCleanupOnDelete.ValueBool() {
+		tflog.Trace(ctx, "skipping service deletion due to no_cleanup_on_delete")
+		return
+	}
+
+	_, err := r.client.DeleteService(ctx, &svc_pb.DeleteServiceReq{
+		Application: data.Application.ValueString(),
+		Service:     data.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete service, got error: %s", err))
+		return
+	}
+	tflog.Trace(ctx, "deleted service resource")
+}
+
+func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var data ServiceResourceModel
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// req.ID is of the form <application>/<service>
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import service, got error: invalid id %s, expected <application>/<service>", req.ID))
+		return
+	}
+
+	data.Application = types.StringValue(parts[0])
+	data.Name = types.StringValue(parts[1])
+	err := r.refresh(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import service state for %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	// Save imported data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
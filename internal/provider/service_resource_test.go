@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccServiceResource(t *testing.T) {
+	appName := uniqueTestName("svc-tests")
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccServiceResourceConfig("my-service", appName, "nginx:1.24", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_service.test", "name", "my-service"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "application", appName),
+					resource.TestCheckResourceAttrSet("prodvana_service.test", "version"),
+					resource.TestCheckResourceAttrSet("prodvana_service.test", "id"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "programs.0.image", "nginx:1.24"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "programs.0.ports.0.port", "80"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "programs.0.ports.0.target_port", "80"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "programs.0.ports.0.protocol", "HTTP"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "replicas", "1"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "per_release_channel.0.release_channel", "staging"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "per_release_channel.0.env.ENV_NAME.value", "staging"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "prodvana_service.test",
+				ImportStateId:     appName + "/my-service",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccServiceResourceConfig("my-service", appName, "nginx:1.25", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("prodvana_service.test", "name", "my-service"),
+					resource.TestCheckResourceAttrSet("prodvana_service.test", "version"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "programs.0.image", "nginx:1.25"),
+					resource.TestCheckResourceAttr("prodvana_service.test", "replicas", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccServiceResourceConfig(name, app, image string, replicas int) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "prodvana_release_channel" "staging" {
+  name        = "staging"
+  application = prodvana_application.app.name
+  runtimes = [
+    {
+      runtime = "default"
+    },
+  ]
+}
+
+resource "prodvana_service" "test" {
+  name        = %[2]q
+  application = prodvana_application.app.name
+  programs = [
+    {
+      name  = "web"
+      image = %[3]q
+      ports = [
+        {
+          port = 80
+        },
+      ]
+    },
+  ]
+  replicas = %[4]d
+  per_release_channel = [
+    {
+      release_channel = prodvana_release_channel.staging.name
+      env = {
+        "ENV_NAME" = { value = "staging" }
+      }
+    },
+  ]
+}
+`, testAccApplicationResourceConfig(app), name, image, replicas)
+}
@@ -2,13 +2,31 @@ package provider
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	ds_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state"
+	"github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/desired_state/model"
 	env_pb "github.com/prodvana/prodvana-public/go/prodvana-sdk/proto/prodvana/environment"
 )
 
 func WaitForClusterWithTimeout(ctx context.Context, client env_pb.EnvironmentManagerClient, clusterId, clusterName, timeoutDuration string) error {
+	return waitForClusterHeartbeat(ctx, client, clusterId, clusterName, timeoutDuration, func(heartbeat time.Time) bool {
+		// consider a heartbeat within 10m as successfully linked
+		healthyTS := time.Now().Add(-time.Minute * 10)
+		return heartbeat.After(healthyTS)
+	})
+}
+
+// WaitForClusterHeartbeatSince waits for a heartbeat sent after since, e.g. by an agent that was just (re)deployed.
+func WaitForClusterHeartbeatSince(ctx context.Context, client env_pb.EnvironmentManagerClient, clusterId, clusterName string, since time.Time, timeoutDuration string) error {
+	return waitForClusterHeartbeat(ctx, client, clusterId, clusterName, timeoutDuration, func(heartbeat time.Time) bool {
+		return heartbeat.After(since)
+	})
+}
+
+func waitForClusterHeartbeat(ctx context.Context, client env_pb.EnvironmentManagerClient, clusterId, clusterName, timeoutDuration string, healthy func(heartbeat time.Time) bool) error {
 	// keep checking to see if linking succeeded until timeout
 	timeout, err := time.ParseDuration(timeoutDuration)
 	if err != nil {
@@ -24,12 +42,8 @@ func WaitForClusterWithTimeout(ctx context.Context, client env_pb.EnvironmentMan
 			return errors.Wrapf(err, "Unable to read runtime link status for %s", clusterName)
 		}
 
-		if statusResp.LastHeartbeatTimestamp != nil {
-			// consider a heartbeat within 10m as successfully linked
-			healthyTS := time.Now().Add(-time.Minute * 10)
-			if statusResp.LastHeartbeatTimestamp.AsTime().After(healthyTS) {
-				return nil
-			}
+		if statusResp.LastHeartbeatTimestamp != nil && healthy(statusResp.LastHeartbeatTimestamp.AsTime()) {
+			return nil
 		}
 
 		if time.Since(startTS) > timeout {
@@ -39,3 +53,45 @@ func WaitForClusterWithTimeout(ctx context.Context, client env_pb.EnvironmentMan
 		time.Sleep(time.Second * 1)
 	}
 }
+
+func WaitForDesiredStateWithTimeout(ctx context.Context, client ds_pb.DesiredStateManagerClient, desiredStateId, timeoutDuration string) (model.Status, error) {
+	// keep checking the convergence status until it settles or we time out
+	timeout, err := time.ParseDuration(timeoutDuration)
+	if err != nil {
+		return model.Status_UNKNOWN_STATUS, errors.Wrapf(err, "Unable to parse timeout duration")
+	}
+
+	startTS := time.Now()
+	for {
+		summaryResp, err := client.GetDesiredStateConvergenceSummary(ctx, &ds_pb.GetDesiredStateConvergenceReq{
+			DesiredStateId: desiredStateId,
+		})
+		if err != nil {
+			return model.Status_UNKNOWN_STATUS, errors.Wrapf(err, "Unable to read convergence status for desired state %s", desiredStateId)
+		}
+
+		summary := summaryResp.GetSummary()
+		switch summary.GetStatus() {
+		case model.Status_CONVERGED:
+			return summary.GetStatus(), nil
+		case model.Status_FAILED, model.Status_ROLLED_BACK, model.Status_FAILED_ROLLBACK, model.Status_REPLACED, model.Status_DELETED:
+			var messages []string
+			for _, explanation := range summary.GetStatusExplanations()[desiredStateId].GetStatusExplanations() {
+				if explanation.Message != "" {
+					messages = append(messages, explanation.Message)
+				}
+				messages = append(messages, explanation.Messages...)
+			}
+			if len(messages) > 0 {
+				return summary.GetStatus(), errors.Errorf("Desired state %s did not converge, status: %s: %s", desiredStateId, summary.GetStatus(), strings.Join(messages, "; "))
+			}
+			return summary.GetStatus(), errors.Errorf("Desired state %s did not converge, status: %s", desiredStateId, summary.GetStatus())
+		}
+
+		if time.Since(startTS) > timeout {
+			return summary.GetStatus(), errors.Errorf("Timeout waiting for desired state %s to converge, last status: %s, timeout: %s", desiredStateId, summary.GetStatus(), timeoutDuration)
+		}
+
+		time.Sleep(time.Second * 1)
+	}
+}
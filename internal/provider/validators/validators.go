@@ -87,3 +87,34 @@ func (v checkPathObjectValidator) ValidateObject(ctx context.Context, req valida
 		}
 	}
 }
+
+// ValidRegex checks that the string is a valid Go regular expression.
+func ValidRegex() validator.String {
+	return validRegexValidator{}
+}
+
+var _ validator.String = validRegexValidator{}
+
+type validRegexValidator struct{}
+
+func (v validRegexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v validRegexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validRegexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path,
+			v.Description(ctx),
+			err.Error(),
+		))
+	}
+}